@@ -0,0 +1,143 @@
+package gotainer
+
+import "reflect"
+
+// Bind makes resolving the interface I return the instance registered for T, following T's
+// lifetime. T must already be registered unless the container defers validation.
+func Bind[I any, T any](container *Container) error {
+	iface := keyOf[I]("")
+	concrete := keyOf[T]("")
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	err := testBinding(container, iface, concrete)
+	if err != nil {
+		return err
+	}
+	if !container.deferValidation && !isRegistered(container, concrete) {
+		return NewPrefetchArgumentError(iface.String(), concrete.String())
+	}
+	if reg, ok := container.registrations[concrete]; ok {
+		err = testBindingGraph(container, iface, concrete, reg.lifetime, reg.deps)
+		if err != nil {
+			return err
+		}
+	}
+
+	addBinding(container, iface, concrete)
+	return nil
+}
+
+func MustBind[I any, T any](container *Container) {
+	err := Bind[I, T](container)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// As binds the interface I to the type being registered, as if Bind had been called afterwards.
+// Named registrations bind I under the same name.
+func As[I any]() RegisterOption {
+	return func(options *registrationOptions) {
+		options.bindings = append(options.bindings, typeOf[I]())
+	}
+}
+
+// testBinding checks iface can be bound to concrete, callers must hold the container lock.
+func testBinding(container *Container, iface key, concrete key) error {
+	if iface.t.Kind() != reflect.Interface {
+		return NewInvalidBindingError(iface.String(), concrete.String(), "only interfaces can be bound")
+	}
+
+	if iface.t == concrete.t {
+		return NewInvalidBindingError(iface.String(), concrete.String(), "an interface cannot be bound to itself")
+	}
+
+	if !injectedType(concrete.t).Implements(iface.t) {
+		return NewInvalidBindingError(iface.String(), concrete.String(), "type does not implement the interface")
+	}
+
+	if isRegistered(container, iface) {
+		return NewDuplicateRegistrationError(iface.qualifiedName())
+	}
+	return nil
+}
+
+// testBindingGraph checks binding iface to concrete, which has lifetime and deps, neither closes a
+// cycle through registrations already depending on iface, such as an Optional of it, nor lets an
+// existing singleton capture a scoped type. Callers must hold the container lock.
+func testBindingGraph(container *Container, iface key, concrete key, lifetime Lifetime, deps []dependency) error {
+	if container.deferValidation {
+		return nil
+	}
+	if cycle := findCycle(container, iface, deps); cycle != nil {
+		return newCircularDependencyError(append([]key{iface, concrete}, cycle[1:]...))
+	}
+
+	scoped, ok := concrete, lifetime == LifetimeScoped
+	if lifetime == LifetimeTransient {
+		visited := make(map[key]bool)
+		for _, dep := range deps {
+			if scoped, ok = findScopedDependency(container, dep.key, visited); ok {
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil
+	}
+	for _, k := range container.order {
+		reg := container.registrations[k]
+		if reg.lifetime == LifetimeSingleton && dependsOn(container, reg.deps, iface, make(map[key]bool)) {
+			return NewScopedDependencyError(k.String(), scoped.String())
+		}
+	}
+	return nil
+}
+
+// dependsOn reports whether any of deps is target, directly or through a chain of transients.
+func dependsOn(container *Container, deps []dependency, target key, visited map[key]bool) bool {
+	for _, dep := range deps {
+		if dep.key == target {
+			return true
+		}
+		reg, ok := container.registrations[dep.key]
+		if !ok || reg.lifetime != LifetimeTransient || visited[dep.key] {
+			continue
+		}
+		visited[dep.key] = true
+		if dependsOn(container, reg.deps, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// addBinding registers iface as an alias of concrete, callers must hold the container lock.
+// Bindings hold no instance of their own, every resolve is delegated to concrete so they are
+// treated as transient.
+func addBinding(container *Container, iface key, concrete key) {
+	container.registrations[iface] = &registration{
+		lifetime: LifetimeTransient,
+		ctor: func(res *resolution) (reflect.Value, error) {
+			val, err := resolveNoReflect(container, concrete, res)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			bound := reflect.New(iface.t).Elem()
+			bound.Set(val)
+			return bound, nil
+		},
+		deps: []dependency{{key: concrete}},
+	}
+	container.order = append(container.order, iface)
+}
+
+// injectedType returns the type a registration is injected as, interfaces are injected as
+// themselves and everything else as a pointer.
+func injectedType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Interface {
+		return t
+	}
+	return reflect.PointerTo(t)
+}
@@ -0,0 +1,234 @@
+package gotainer_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestBind_SingletonConcreteType_ResolvesSameInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[PostgresStore](c, NewPostgresStore)
+	err := gotainer.Bind[Store, PostgresStore](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	store, err := gotainer.ResolveInterface[Store](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	concrete := gotainer.MustResolve[PostgresStore](c)
+
+	if store != Store(concrete) {
+		t.Error("expected bound interface to resolve the concrete singleton")
+	}
+}
+
+func TestBind_TransientConcreteType_ResolvesNewInstances(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[PostgresStore](c, NewPostgresStore)
+	gotainer.MustBind[Store, PostgresStore](c)
+
+	first := gotainer.MustResolveInterface[Store](c)
+	second := gotainer.MustResolveInterface[Store](c)
+
+	if first == second {
+		t.Error("expected bound interface to follow the transient lifetime of the concrete type")
+	}
+	if _, ok := first.(*PostgresStore); !ok {
+		t.Errorf("expected bound interface to hold *PostgresStore, got %T", first)
+	}
+}
+
+func TestBind_BoundInterfaceParam_InjectsConcreteInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[PostgresStore](c, NewPostgresStore)
+	gotainer.MustBind[Store, PostgresStore](c)
+	gotainer.MustRegisterTransient[StoreConsumer](c, NewStoreConsumer)
+
+	consumer := gotainer.MustResolve[StoreConsumer](c)
+
+	if consumer.store != Store(gotainer.MustResolve[PostgresStore](c)) {
+		t.Error("expected injected interface to be the concrete singleton")
+	}
+	if consumer.store.Get("key") != "postgres://localhost/key" {
+		t.Errorf("expected injected interface methods to work, got %q", consumer.store.Get("key"))
+	}
+}
+
+func TestBind_AsOption_ResolvesSameInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[PostgresStore](c, NewPostgresStore, gotainer.As[Store]())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	store := gotainer.MustResolveInterface[Store](c)
+
+	if store != Store(gotainer.MustResolve[PostgresStore](c)) {
+		t.Error("expected interface bound with As to resolve the concrete singleton")
+	}
+}
+
+func TestBind_AsOptionNotImplemented_ReturnsErrorAndDoesNotRegister(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[SimpleStruct](c, NewSimpleStruct, gotainer.As[Store]())
+
+	bindingErr := &gotainer.InvalidBindingError{}
+	if !errors.As(err, &bindingErr) {
+		t.Errorf("expected error to be InvalidBindingError, got %v", err)
+		return
+	}
+
+	_, err = gotainer.Resolve[SimpleStruct](c)
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected failed registration to register nothing, got %v", err)
+	}
+}
+
+func TestBind_TypeNotImplementingInterface_ReturnsInvalidBindingError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+
+	err := gotainer.Bind[Store, SimpleStruct](c)
+
+	bindingErr := &gotainer.InvalidBindingError{}
+	if !errors.As(err, &bindingErr) {
+		t.Errorf("expected error to be InvalidBindingError, got %v", err)
+		return
+	}
+
+	if bindingErr.InterfaceName != "Store" || bindingErr.TypeName != "SimpleStruct" {
+		t.Errorf("expected error for Store and SimpleStruct, got %s and %s", bindingErr.InterfaceName, bindingErr.TypeName)
+	}
+}
+
+func TestBind_NonInterface_ReturnsInvalidBindingError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[PostgresStore](c, NewPostgresStore)
+
+	err := gotainer.Bind[SimpleStruct, PostgresStore](c)
+
+	bindingErr := &gotainer.InvalidBindingError{}
+	if !errors.As(err, &bindingErr) {
+		t.Errorf("expected error to be InvalidBindingError, got %v", err)
+	}
+}
+
+func TestBind_UnregisteredConcreteType_ReturnsPrefetchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.Bind[Store, PostgresStore](c)
+
+	prefetchErr := &gotainer.PrefetchArgumentError{}
+	if !errors.As(err, &prefetchErr) {
+		t.Errorf("expected error to be PrefetchArgumentError, got %v", err)
+	}
+}
+
+func TestBind_AlreadyRegisteredInterface_ReturnsDuplicateRegistrationError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[PostgresStore](c, NewPostgresStore, gotainer.As[Store]())
+
+	err := gotainer.Bind[Store, PostgresStore](c)
+
+	dupErr := &gotainer.DuplicateRegistrationError{}
+	if !errors.As(err, &dupErr) {
+		t.Errorf("expected error to be DuplicateRegistrationError, got %v", err)
+	}
+}
+
+func TestBind_DeferredBindingBeforeConcreteType_Validates(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterTransient[StoreConsumer](c, NewStoreConsumer)
+	gotainer.MustBind[Store, PostgresStore](c)
+	gotainer.MustRegisterSingleton[PostgresStore](c, NewPostgresStore)
+
+	err := c.Validate()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	consumer := gotainer.MustResolve[StoreConsumer](c)
+	if consumer.store != Store(gotainer.MustResolve[PostgresStore](c)) {
+		t.Error("expected injected interface to be the concrete singleton")
+	}
+}
+
+func TestBind_AsOptionClosingCycle_ReturnsCircularDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterSingleton[SelfReportingExporter](c, NewSelfReportingExporter, gotainer.As[MetricsExporter]())
+
+	cycleErr := &gotainer.CircularDependencyError{}
+	if !errors.As(err, &cycleErr) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+		return
+	}
+	expected := []string{"MetricsExporter", "SelfReportingExporter", "MetricsExporter"}
+	if !slices.Equal(cycleErr.Chain, expected) {
+		t.Errorf("expected chain %v, got %v", expected, cycleErr.Chain)
+	}
+}
+
+func TestBind_BindingClosingCycle_ReturnsCircularDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SelfReportingExporter](c, NewSelfReportingExporter)
+
+	err := gotainer.Bind[MetricsExporter, SelfReportingExporter](c)
+
+	cycleErr := &gotainer.CircularDependencyError{}
+	if !errors.As(err, &cycleErr) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+		return
+	}
+	_, err = gotainer.ResolveInterface[MetricsExporter](c)
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected failed binding to register nothing, got %v", err)
+	}
+}
+
+func TestBind_ScopedTypeForOptionalOfSingleton_ReturnsScopedDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[InstrumentedService](c, NewInstrumentedService)
+	gotainer.MustRegisterScoped[CountingMetricsExporter](c, NewCountingMetricsExporterStruct)
+
+	err := gotainer.Bind[MetricsExporter, CountingMetricsExporter](c)
+
+	scopedErr := &gotainer.ScopedDependencyError{}
+	if !errors.As(err, &scopedErr) {
+		t.Errorf("expected error to be ScopedDependencyError, got %v", err)
+	}
+}
+
+func TestBind_AsOptionOnScopedForOptionalOfSingleton_ReturnsScopedDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[InstrumentedService](c, NewInstrumentedService)
+
+	err := gotainer.RegisterScoped[CountingMetricsExporter](c, NewCountingMetricsExporterStruct, gotainer.As[MetricsExporter]())
+
+	scopedErr := &gotainer.ScopedDependencyError{}
+	if !errors.As(err, &scopedErr) {
+		t.Errorf("expected error to be ScopedDependencyError, got %v", err)
+	}
+}
+
+func TestBind_AsOptionTwice_ReturnsDuplicateRegistrationError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterSingleton[PostgresStore](c, NewPostgresStore, gotainer.As[Store](), gotainer.As[Store]())
+
+	dupErr := &gotainer.DuplicateRegistrationError{}
+	if !errors.As(err, &dupErr) {
+		t.Errorf("expected error to be DuplicateRegistrationError, got %v", err)
+	}
+}
@@ -23,14 +23,10 @@ type SimpleInterface interface {
 }
 
 func main() {
-	// todo: maintain ordering. right now loops are possible. We need to check that our reqs exists before we allow registration.
-	// todo: allow resolution of FNs which are unregistered. For example "fill this fn with services"
 	c := gotainer.NewContainer()
 	gotainer.MustRegisterTransient[SimpleInterface](c, NewSimpleStruct)
 
-	service, err := gotainer.ResolveInterface[SimpleInterface](c)
-	if err != nil {
-		panic(err)
-	}
-	service.DoSomething()
+	gotainer.MustInvoke(c, func(service SimpleInterface) {
+		service.DoSomething()
+	})
 }
@@ -1,230 +1,593 @@
 package gotainer
 
 import (
+	"context"
 	"fmt"
 	"reflect"
-	"unsafe"
+	"slices"
+	"sync"
+	"sync/atomic"
 )
 
-type unsafeCtor func() (unsafe.Pointer, error)
+var (
+	errorType           = reflect.TypeOf((*error)(nil)).Elem()
+	stringType          = reflect.TypeOf("")
+	cleanupType         = reflect.TypeOf((func())(nil))
+	erroringCleanupType = reflect.TypeOf((func() error)(nil))
+)
+
+// Lifetime describes how long a constructed instance of a registration is reused for.
+type Lifetime int
+
+const (
+	LifetimeTransient Lifetime = iota
+	LifetimeSingleton
+	LifetimeScoped
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case LifetimeTransient:
+		return "transient"
+	case LifetimeSingleton:
+		return "singleton"
+	case LifetimeScoped:
+		return "scoped"
+	}
+	return "unknown"
+}
 
+// ctorFunc constructs an instance of a registration. The value has the ctor's first return type,
+// a pointer for struct registrations and the interface itself for interface registrations.
+type ctorFunc func(res *resolution) (reflect.Value, error)
+
+type registration struct {
+	lifetime Lifetime
+	ctor     ctorFunc
+	deps     []dependency
+	// mapKeys holds the map key of each dependency of a map group
+	mapKeys []string
+	// eager singletons are constructed by Container.Start
+	eager bool
+}
+
+// key identifies a registration. Types registered without a name share the empty name, so
+// several registrations of the same type can sit alongside each other under different names.
+type key struct {
+	t    reflect.Type
+	name string
+	// group keys resolve every member of a group, t is the slice or map the members are collected into
+	group bool
+	// member numbers anonymous group members of t, counting from 1
+	member int
+}
+
+func keyOf[T any](name string) key {
+	return key{t: typeOf[T](), name: name}
+}
+
+// String returns the short name of the key, named keys are written as Type[name] and group
+// members as Type#position.
+func (k key) String() string {
+	return k.format(typeName(k.t))
+}
+
+// qualifiedName returns the package path qualified name of the key.
+func (k key) qualifiedName() string {
+	return k.format(qualifiedTypeName(k.t))
+}
+
+func (k key) format(name string) string {
+	if k.name != "" {
+		name = fmt.Sprintf("%s[%s]", name, k.name)
+	}
+	if k.member != 0 {
+		name = fmt.Sprintf("%s#%d", name, k.member)
+	}
+	return name
+}
+
+// Container registrations are keyed by the full reflect.Type and an optional name so that
+// same-named types from different packages, and unnamed types, never share a registration.
+// A Container is safe for concurrent registration and resolution.
 type Container struct {
-	singletonCtors map[string]unsafe.Pointer
-	transientCtors map[string]unsafe.Pointer
-	singletons     map[string]unsafe.Pointer
+	mu            sync.RWMutex
+	registrations map[key]*registration
+	singletons    map[key]reflect.Value
+	// order holds every registered key in registration order, giving graph walks a stable order
+	order           []key
+	disposables     disposables
+	deferValidation bool
 }
 
-func NewContainer() *Container {
-	return &Container{
-		singletonCtors: make(map[string]unsafe.Pointer),
-		transientCtors: make(map[string]unsafe.Pointer),
-		singletons:     make(map[string]unsafe.Pointer),
+func NewContainer(opts ...ContainerOption) *Container {
+	container := &Container{
+		registrations: make(map[key]*registration),
+		singletons:    make(map[key]reflect.Value),
+	}
+	for _, opt := range opts {
+		opt(container)
 	}
+	return container
 }
 
-func ResolveInterface[T any](container *Container) (T, error) {
-	t := reflect.TypeOf((*T)(nil)).Elem()
-	var defaultVal T
-	ptr, err := resolveNoReflect(container, t.Name())
-	if err != nil {
-		return defaultVal, err
+// Resolver is implemented by the root Container and by each Scope created from it.
+type Resolver interface {
+	resolve(ctx context.Context, k key) (reflect.Value, error)
+	root() *Container
+}
+
+func (c *Container) root() *Container {
+	return c
+}
+
+func (c *Container) resolve(ctx context.Context, k key) (reflect.Value, error) {
+	if c.disposables.isClosed() {
+		return reflect.Value{}, NewContainerClosedError()
 	}
-	herp := (*T)(ptr)
-	fmt.Println(herp)
-	return *(*T)(ptr), nil
+	return resolveNoReflect(c, k, newResolution(ctx, nil))
+}
+
+func ResolveInterface[T any](resolver Resolver) (T, error) {
+	return ResolveInterfaceNamed[T](resolver, "")
 }
 
-func MustResolveInterface[T any](container *Container) T {
-	res, err := ResolveInterface[T](container)
+func MustResolveInterface[T any](resolver Resolver) T {
+	res, err := ResolveInterface[T](resolver)
 	if err != nil {
 		panic(err)
 	}
 	return res
 }
 
-func Resolve[T any](container *Container) (*T, error) {
-	t := reflect.TypeOf((*T)(nil)).Elem()
-	ptr, err := resolveNoReflect(container, t.Name())
-	if err != nil {
-		return nil, err
-	}
-	return (*T)(ptr), nil
+func Resolve[T any](resolver Resolver) (*T, error) {
+	return ResolveNamed[T](resolver, "")
 }
 
-func MustResolve[T any](container *Container) *T {
-	res, err := Resolve[T](container)
+func MustResolve[T any](resolver Resolver) *T {
+	res, err := Resolve[T](resolver)
 	if err != nil {
 		panic(err)
 	}
 	return res
 }
 
-func MustRegisterTransient[T any, Fn any](container *Container, ctor Fn) {
-	err := RegisterTransient[T, Fn](container, ctor)
+func MustRegisterTransient[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) {
+	err := RegisterTransient[T, Fn](container, ctor, opts...)
 	if err != nil {
 		panic(err)
 	}
 }
 
-func MustRegisterSingleton[T any, Fn any](container *Container, ctor Fn) {
-	err := RegisterSingleton[T, Fn](container, ctor)
+func MustRegisterSingleton[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) {
+	err := RegisterSingleton[T, Fn](container, ctor, opts...)
 	if err != nil {
 		panic(err)
 	}
 }
 
-func RegisterTransient[T any, Fn any](container *Container, ctor Fn) error {
-	t := reflect.TypeOf((*T)(nil)).Elem()
-	fnType := reflect.TypeOf(ctor)
-	err := testFn(container, t, fnType)
+func MustRegisterScoped[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) {
+	err := RegisterScoped[T, Fn](container, ctor, opts...)
 	if err != nil {
-		return err
+		panic(err)
 	}
+}
 
-	wrappedCtor := wrapCtor[T, Fn](container, fnType, &ctor, t.Kind() == reflect.Interface)
-	container.transientCtors[t.Name()] = unsafe.Pointer(&wrappedCtor)
-	return nil
+// RegisterTransient registers a type which is constructed on every resolve. A transient whose ctor
+// returns a cleanup must be resolved from a Scope or as a dependency of a singleton, the cleanup is
+// only run when its owner is closed and the root Container would otherwise collect one per resolve.
+func RegisterTransient[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) error {
+	return register(container, keyOf[T](""), ctor, LifetimeTransient, opts)
+}
+
+// RegisterScoped registers a type which is constructed once per Scope. Scoped types can only be
+// resolved from a Scope, and singletons may not depend on them.
+func RegisterScoped[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) error {
+	return register(container, keyOf[T](""), ctor, LifetimeScoped, opts)
+}
+
+func RegisterSingleton[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) error {
+	return register(container, keyOf[T](""), ctor, LifetimeSingleton, opts)
+}
+
+func register(container *Container, k key, ctor any, lifetime Lifetime, opts []RegisterOption) error {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	return registerLocked(container, k, ctor, lifetime, opts)
 }
 
-func RegisterSingleton[T any, Fn any](container *Container, ctor Fn) error {
-	t := reflect.TypeOf((*T)(nil)).Elem()
+// registerLocked validates and adds a registration, callers must hold the container lock.
+func registerLocked(container *Container, k key, ctor any, lifetime Lifetime, opts []RegisterOption) error {
 	fnType := reflect.TypeOf(ctor)
-	err := testFn(container, t, fnType)
+	options := newRegistrationOptions(opts)
+
+	err := testFn(container, k, fnType, options.paramNames)
 	if err != nil {
 		return err
 	}
+	err = testOptions(fnType, lifetime, options)
+	if err != nil {
+		return err
+	}
+	params := paramsOf(fnType, options.paramNames)
+	deps := dependenciesOfParams(params)
+	for i, iface := range options.bindings {
+		binding := key{t: iface, name: k.name}
+		if slices.Contains(options.bindings[:i], iface) {
+			return NewDuplicateRegistrationError(binding.qualifiedName())
+		}
+		err = testBinding(container, binding, k)
+		if err != nil {
+			return err
+		}
+		err = testBindingGraph(container, binding, k, lifetime, deps)
+		if err != nil {
+			return err
+		}
+	}
+	for i, membership := range options.groups {
+		// entries added by this registration are not in the map yet, so check them against each other
+		if membership.group.t.Kind() == reflect.Map && slices.Contains(options.groups[:i], membership) {
+			return NewDuplicateMapKeyError(membership.group.String(), membership.mapKey)
+		}
+		err = testGroupMember(container, membership, k, deps)
+		if err != nil {
+			return err
+		}
+	}
+	if lifetime == LifetimeSingleton && !container.deferValidation {
+		err = findScopedDependencyErrors(container, deps, k)
+		if err != nil {
+			return err
+		}
+	}
 
-	wrappedCtor := wrapCtor[T, Fn](container, fnType, &ctor, t.Kind() == reflect.Interface)
-	wrappedSingletonCtor := wrapSingletonCtor[T](container, t.Name(), wrappedCtor)
-	container.singletonCtors[t.Name()] = unsafe.Pointer(&wrappedSingletonCtor)
+	wrappedCtor := wrapCtor(container, reflect.ValueOf(ctor), params)
+	if fnType.Out(0).Kind() == reflect.Struct {
+		wrappedCtor = wrapResultCtor(wrappedCtor)
+	}
+	switch lifetime {
+	case LifetimeTransient:
+		if fnType.NumOut() == 3 {
+			wrappedCtor = wrapTransientCleanupCtor(k, wrappedCtor)
+		}
+	case LifetimeSingleton:
+		wrappedCtor = wrapSingletonCtor(container, k, wrappedCtor, newDisposer(fnType, options))
+	case LifetimeScoped:
+		wrappedCtor = wrapScopedCtor(k, wrappedCtor, newDisposer(fnType, options))
+	}
+	container.registrations[k] = &registration{
+		lifetime: lifetime,
+		ctor:     wrappedCtor,
+		deps:     deps,
+		eager:    options.eager,
+	}
+	container.order = append(container.order, k)
+	for _, iface := range options.bindings {
+		addBinding(container, key{t: iface, name: k.name}, k)
+	}
+	for _, membership := range options.groups {
+		addGroupMember(container, membership, k)
+	}
+	if isResultObject(k.t) {
+		addResultFields(container, k)
+	}
 	return nil
 }
 
-func testFn(container *Container, contentType reflect.Type, fnType reflect.Type) error {
-	if fnType.Kind() != reflect.Func {
+// testFn validates a ctor against the registered types, callers must hold the container lock.
+func testFn(container *Container, k key, fnType reflect.Type, paramNames []string) error {
+	if fnType == nil || fnType.Kind() != reflect.Func {
 		return NewConstructorMismatchError("ctor must be a function")
 	}
 
-	if fnType.NumOut() != 2 {
-		return NewConstructorMismatchError("ctor must have 2 return values")
+	if fnType.NumOut() != 2 && fnType.NumOut() != 3 {
+		return NewConstructorMismatchError("ctor must have 2 or 3 return values")
 	}
 
-	if fnType.Out(1).Name() != "error" {
-		return NewConstructorMismatchError("ctor must return an error as the second return value")
+	if fnType.Out(fnType.NumOut()-1) != errorType {
+		return NewConstructorMismatchError("ctor must return an error as the last return value")
 	}
 
-	firstOut := fnType.Out(0)
-	if firstOut.Kind() != reflect.Ptr && firstOut.Kind() != reflect.Interface {
-		return NewConstructorMismatchError("ctor must return a pointer or interface to the type it is constructing")
+	if fnType.NumOut() == 3 && fnType.Out(1) != cleanupType && fnType.Out(1) != erroringCleanupType {
+		return NewConstructorMismatchError("ctor must return a func() or func() error cleanup as the second of 3 return values")
 	}
 
-	if firstOut.Kind() != reflect.Ptr &&
-		firstOut.Kind() == reflect.Interface &&
-		firstOut.Name() != contentType.Name() {
+	contentType := k.t
+	firstOut := fnType.Out(0)
+	switch {
+	case firstOut == contentType && isResultObject(contentType):
+		// result objects may be returned by value, they are stored as a pointer like any other struct
+	case firstOut.Kind() != reflect.Ptr && firstOut.Kind() != reflect.Interface:
+		return NewConstructorMismatchError("ctor must return a pointer or interface to the type it is constructing")
+	case firstOut.Kind() == reflect.Interface && firstOut != contentType:
 		return NewConstructorMismatchError("ctor must return an interface to the type it is constructing when registering an interface")
-	} else if firstOut.Kind() != reflect.Interface && firstOut.Elem().Name() != contentType.Name() {
+	case firstOut.Kind() == reflect.Ptr && firstOut.Elem() != contentType:
 		return NewConstructorMismatchError("ctor must return a pointer to the type it is constructing when registering a struct type")
 	}
 
-	return findPrefetchErrors(container, fnType, contentType.Name())
-}
+	if reason, ok := findInvalidParam(fnType); ok {
+		return NewConstructorMismatchError("ctor " + reason)
+	}
 
-func findPrefetchErrors(container *Container, funcType reflect.Type, typeName string) error {
-	inputCount := funcType.NumIn()
-	if inputCount == 0 {
-		return nil
+	if len(paramNames) > fnType.NumIn() {
+		return NewConstructorMismatchError(fmt.Sprintf("ctor has %d parameters but %d parameter names were given", fnType.NumIn(), len(paramNames)))
+	}
+	for i, name := range paramNames {
+		if name != "" && isGroupParam(fnType.In(i)) {
+			return NewConstructorMismatchError(fmt.Sprintf("ctor parameter %s receives a group and cannot be resolved by name", fnType.In(i)))
+		}
+		if name != "" && isParamObject(fnType.In(i)) {
+			return NewConstructorMismatchError(fmt.Sprintf("ctor parameter %s is a parameter object and cannot be resolved by name, tag its fields instead", fnType.In(i)))
+		}
+		if name != "" && fnType.In(i) == contextType {
+			return NewConstructorMismatchError("ctor parameter context.Context receives the resolve context and cannot be resolved by name")
+		}
+	}
+
+	if isRegistered(container, k) {
+		return NewDuplicateRegistrationError(k.qualifiedName())
 	}
-	for i := 0; i < inputCount; i++ {
-		input := funcType.In(i)
-		name := input.Name()
-		if input.Kind() == reflect.Ptr {
-			name = input.Elem().Name()
+
+	deps := dependenciesOfParams(paramsOf(fnType, paramNames))
+	if isResultObject(contentType) {
+		err := testResultObject(container, k, deps)
+		if err != nil {
+			return err
 		}
+	}
 
-		_, isSingleton := container.singletonCtors[name]
-		_, isTransient := container.transientCtors[name]
-		if !isSingleton && !isTransient {
-			return NewPrefetchArgumentError(typeName, name)
+	// in deferred mode the graph is checked as a whole by Validate once registration is complete
+	if container.deferValidation {
+		return nil
+	}
+
+	if cycle := findCycle(container, k, deps); cycle != nil {
+		return newCircularDependencyError(cycle)
+	}
+
+	return findPrefetchErrors(container, deps, k.String())
+}
+
+// findPrefetchErrors requires dependencies to be registered before the types depending on them,
+// except deferred dependencies which may be registered afterwards to break a cycle.
+func findPrefetchErrors(container *Container, deps []dependency, parentName string) error {
+	for _, dep := range deps {
+		if !dep.optional && !dep.deferred && !isRegistered(container, dep.key) {
+			return NewPrefetchArgumentError(parentName, dep.key.String())
 		}
 	}
 
 	return nil
 }
 
-func wrapCtor[T any, Fn any](container *Container, funcType reflect.Type, ctor *Fn, isInterface bool) unsafeCtor {
-	return func() (unsafe.Pointer, error) {
-		inputCount := funcType.NumIn()
-		if inputCount == 0 {
-			if isInterface {
-				return unpackInterfaceCall(reflect.ValueOf(*ctor).Call([]reflect.Value{}))
-			}
-			return unpackStructCall(reflect.ValueOf(*ctor).Call([]reflect.Value{}))
+// findScopedDependencyErrors rejects singletons which would capture a scoped type, either
+// directly or through a chain of transients.
+func findScopedDependencyErrors(container *Container, deps []dependency, parent key) error {
+	visited := make(map[key]bool)
+	for _, dep := range deps {
+		scoped, ok := findScopedDependency(container, dep.key, visited)
+		if ok {
+			return NewScopedDependencyError(parent.String(), scoped.String())
 		}
+	}
+	return nil
+}
 
-		vals := make([]reflect.Value, inputCount)
-		for i := 0; i < inputCount; i++ {
-			input := funcType.In(i)
-			name := input.Name()
-			if input.Kind() == reflect.Ptr {
-				name = input.Elem().Name()
-			}
-			resolvedInput, err := resolveNoReflect(container, name)
+func findScopedDependency(container *Container, k key, visited map[key]bool) (key, bool) {
+	reg, ok := container.registrations[k]
+	if !ok {
+		return key{}, false
+	}
+	if reg.lifetime == LifetimeScoped {
+		return k, true
+	}
+	// singletons are checked on their own, so only transients need walking
+	if reg.lifetime != LifetimeTransient || visited[k] {
+		return key{}, false
+	}
+	visited[k] = true
+	for _, dep := range reg.deps {
+		scoped, ok := findScopedDependency(container, dep.key, visited)
+		if ok {
+			return scoped, true
+		}
+	}
+	return key{}, false
+}
+
+// isRegistered reports whether k can be resolved, groups always can as they may be empty.
+func isRegistered(container *Container, k key) bool {
+	_, ok := container.registrations[k]
+	return ok || k.group
+}
+
+// dependenciesOf returns the dependencies of a registered key, or nil if k is not registered.
+func dependenciesOf(container *Container, k key) []dependency {
+	reg, ok := container.registrations[k]
+	if !ok {
+		return nil
+	}
+	return reg.deps
+}
+
+// typeOf returns the reflect.Type of T, including when T is an interface.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// valueAs converts a resolved value back to the static type requested by a typed accessor, nil
+// interfaces become the zero value of V.
+func valueAs[V any](val reflect.Value) V {
+	var res V
+	if val.IsValid() {
+		reflect.ValueOf(&res).Elem().Set(val)
+	}
+	return res
+}
+
+// typeName returns the short name of a type, falling back to its full description for
+// unnamed types such as slices, maps and funcs.
+func typeName(t reflect.Type) string {
+	if t.Name() == "" {
+		return t.String()
+	}
+	return t.Name()
+}
+
+// qualifiedTypeName returns the package path qualified name of a type, which is unique
+// within a program unlike the short name.
+func qualifiedTypeName(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+func wrapCtor(container *Container, ctor reflect.Value, params []param) ctorFunc {
+	return func(res *resolution) (reflect.Value, error) {
+		// Lazy and Provider dependencies resolved while the ctor runs are part of this resolution, so
+		// cycles are still caught, afterwards they resolve on their own like any other resolve
+		var constructing atomic.Bool
+		constructing.Store(true)
+		inj := &injector{
+			ctx:       res.ctx,
+			container: container,
+			resolve: func(k key) (reflect.Value, error) {
+				return resolveNoReflect(container, k, res)
+			},
+			resolveDeferred: func(k key) (reflect.Value, error) {
+				if constructing.Load() {
+					return resolveNoReflect(container, k, res)
+				}
+				// the resolve context may well be cancelled by the time a Lazy or Provider is used
+				if res.scope != nil {
+					return res.scope.resolve(context.Background(), k)
+				}
+				return container.resolve(context.Background(), k)
+			},
+		}
+		// a cancelled resolve stops before constructing anything further down the graph, instances
+		// which were already built are still handed out
+		if err := res.ctx.Err(); err != nil {
+			return reflect.Value{}, err
+		}
+		vals, err := resolveArgs(inj, params)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if err := res.ctx.Err(); err != nil {
+			return reflect.Value{}, err
+		}
+
+		results := ctor.Call(vals)
+		constructing.Store(false)
+		errVal := results[len(results)-1]
+		if !errVal.IsNil() {
+			return reflect.Value{}, errVal.Interface().(error)
+		}
+		if len(results) == 3 {
+			// the cleanup is owned by whoever is resolving, recorded now so it runs after anything built on top of it
+			err = ownerOf(container, res.scope).add(ctorCleanup(results[1]))
 			if err != nil {
-				return nil, err
+				return reflect.Value{}, err
 			}
-			vals[i] = reflect.NewAt(input, unsafe.Pointer(&resolvedInput)).Elem()
 		}
+		return results[0], nil
+	}
+}
 
-		if isInterface {
-			return unpackInterfaceCall(reflect.ValueOf(*ctor).Call(vals))
+// ctorCleanup adapts the cleanup returned by a ctor, either func() or func() error.
+func ctorCleanup(cleanup reflect.Value) func() error {
+	if cleanup.IsNil() {
+		return nil
+	}
+	switch fn := cleanup.Interface().(type) {
+	case func() error:
+		return fn
+	case func():
+		return func() error {
+			fn()
+			return nil
 		}
-		return unpackStructCall(reflect.ValueOf(*ctor).Call(vals))
 	}
+	return nil
 }
 
-func unpackInterfaceCall(args []reflect.Value) (unsafe.Pointer, error) {
-	if !args[1].IsNil() {
-		return nil, args[1].Interface().(error)
+func resolveNoReflect(container *Container, k key, res *resolution) (reflect.Value, error) {
+	// ctors are looked up under the read lock but invoked outside of it, they resolve their own dependencies
+	container.mu.RLock()
+	reg, ok := container.registrations[k]
+	container.mu.RUnlock()
+	if !ok && k.group {
+		return emptyGroup(k), nil
+	}
+	if !ok {
+		return reflect.Value{}, NewTypeNotRegisteredError(k.qualifiedName(), findSimilarRegistrations(container, k))
 	}
-	int := args[0].Interface()
-	return unsafe.Pointer(&int), nil
-}
 
-func unpackStructCall(args []reflect.Value) (unsafe.Pointer, error) {
-	var argOne unsafe.Pointer
-	var argTwo error
-	if !args[0].IsNil() {
-		argOne = args[0].UnsafePointer()
+	// registration rejects cycles, this is a safety net which turns a stack overflow into an error
+	res, err := res.enter(k, reg.lifetime)
+	if err != nil {
+		return reflect.Value{}, err
 	}
-	if !args[1].IsNil() {
-		argTwo = args[1].Interface().(error)
+
+	constructed, err := reg.ctor(res)
+	if err != nil {
+		return reflect.Value{}, res.wrapError(err)
 	}
-	return argOne, argTwo
+	return constructed, nil
 }
 
-func resolveNoReflect(container *Container, name string) (unsafe.Pointer, error) {
-	singletonCtor, ok := container.singletonCtors[name]
-	if ok {
-		return (*(*unsafeCtor)(singletonCtor))()
+// wrapTransientCleanupCtor rejects resolving a transient which returns a cleanup from the root
+// Container unless a singleton is being constructed, which bounds the cleanups it collects.
+func wrapTransientCleanupCtor(k key, ctor ctorFunc) ctorFunc {
+	return func(res *resolution) (reflect.Value, error) {
+		if res.scope == nil && !res.constructingSingleton() {
+			return reflect.Value{}, NewCleanupScopeRequiredError(k.String())
+		}
+		return ctor(res)
 	}
-
-	// no need to check for ok here, if it's not a singleton it must be a transient since we prefetch check
-	transientCtor, _ := container.transientCtors[name]
-	return (*(*unsafeCtor)(transientCtor))()
 }
 
-func wrapSingletonCtor[T any](container *Container, name string, ctor unsafeCtor) unsafeCtor {
-	return func() (unsafe.Pointer, error) {
-		singleton, ok := container.singletons[name]
+func wrapSingletonCtor(container *Container, k key, ctor ctorFunc, dispose disposer) ctorFunc {
+	// each singleton gets its own construction lock so that it is built exactly once without
+	// blocking resolution of unrelated types
+	var constructMu sync.Mutex
+	return func(res *resolution) (reflect.Value, error) {
+		singleton, ok := loadSingleton(container, k)
+		if ok {
+			return singleton, nil
+		}
+
+		constructMu.Lock()
+		defer constructMu.Unlock()
+		// another goroutine may have finished construction while we waited
+		singleton, ok = loadSingleton(container, k)
 		if ok {
 			return singleton, nil
 		}
 
-		constructed, err := ctor()
+		// singletons always resolve their dependencies from the root, never from the requesting scope
+		constructed, err := ctor(res.withScope(nil))
 		if err != nil {
-			return nil, err
+			return reflect.Value{}, err
 		}
-		container.singletons[name] = unsafe.Pointer(constructed)
+		// a container closed during construction tears the singleton down rather than storing it
+		err = container.disposables.add(dispose(constructed))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		container.mu.Lock()
+		container.singletons[k] = constructed
+		container.mu.Unlock()
 		return constructed, nil
 	}
 }
+
+func loadSingleton(container *Container, k key) (reflect.Value, bool) {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	singleton, ok := container.singletons[k]
+	return singleton, ok
+}
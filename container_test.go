@@ -2,7 +2,13 @@ package gotainer_test
 
 import (
 	"errors"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/BlindGarret/gotainer"
 )
@@ -475,3 +481,463 @@ func TestContainer_ResolveTransientInterface_Resolves(t *testing.T) {
 
 	resolved.DoThing()
 }
+
+func TestContainer_ResolveInterfaceOfStructType_ReturnsCopy(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+	singleton := gotainer.MustResolve[SimpleStruct](c)
+
+	resolved, err := gotainer.ResolveInterface[SimpleStruct](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resolved.data != 1 {
+		t.Errorf("expected a copy of the registered struct, got %v", resolved)
+		return
+	}
+	resolved.data = 2
+	if singleton.data != 1 {
+		t.Error("expected changes to the copy not to affect the singleton")
+	}
+}
+
+func TestContainer_RegisterSameNamedTypes_ResolvesEachType(t *testing.T) {
+	// a locally declared type shares the short name of the package level SimpleStruct
+	type SimpleStruct struct {
+		data string
+	}
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[SimpleStruct](c, func() (*SimpleStruct, error) {
+		return &SimpleStruct{data: "local"}, nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = gotainer.RegisterSingleton[SimpleStructAlias](c, NewSimpleStruct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	local, err := gotainer.Resolve[SimpleStruct](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pkgLevel, err := gotainer.Resolve[SimpleStructAlias](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if local.data != "local" {
+		t.Errorf("expected local type to resolve from its own ctor, got %q", local.data)
+	}
+	if pkgLevel.data != 1 {
+		t.Errorf("expected package level type to resolve from its own ctor, got %d", pkgLevel.data)
+	}
+}
+
+func TestContainer_RegisterUnnamedTypes_ResolvesEachType(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[[]int](c, NewIntSlice)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = gotainer.RegisterSingleton[map[string]string](c, NewStringMap)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	slice, err := gotainer.Resolve[[]int](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	m, err := gotainer.Resolve[map[string]string](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(*slice) != 3 {
+		t.Errorf("expected slice of length 3, got %v", *slice)
+	}
+	if (*m)["key"] != "value" {
+		t.Errorf("expected map to contain key, got %v", *m)
+	}
+}
+
+func TestContainer_RegisterSameTypeTwice_ReturnsDuplicateRegistrationError(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = gotainer.RegisterTransient[SimpleStruct](c, NewSimpleStruct)
+	if err == nil {
+		t.Error("expected error when registering the same type twice")
+		return
+	}
+
+	dupErr := &gotainer.DuplicateRegistrationError{}
+	if !errors.As(err, &dupErr) {
+		t.Errorf("expected error to be DuplicateRegistrationError, got %v", err)
+		return
+	}
+
+	if dupErr.TypeName != "github.com/BlindGarret/gotainer_test.SimpleStruct" {
+		t.Errorf("expected error to name the package qualified type, got %s", dupErr.TypeName)
+	}
+}
+
+func TestContainer_ConcurrentResolveSingleton_ConstructsOnce(t *testing.T) {
+	c := gotainer.NewContainer()
+	var constructions atomic.Int32
+	err := gotainer.RegisterSingleton[SimpleStruct](c, func() (*SimpleStruct, error) {
+		constructions.Add(1)
+		// widen the window in which racing resolves could double construct
+		time.Sleep(10 * time.Millisecond)
+		return &SimpleStruct{data: 1}, nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	const workers = 50
+	results := make([]*SimpleStruct, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = gotainer.Resolve[SimpleStruct](c)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		if errs[i] != nil {
+			t.Error(errs[i])
+			return
+		}
+		if results[i] != results[0] {
+			t.Error("singletons when resolved concurrently should be the same reference")
+			return
+		}
+	}
+
+	if constructions.Load() != 1 {
+		t.Errorf("expected singleton to be constructed once, was constructed %d times", constructions.Load())
+	}
+}
+
+func TestContainer_ConcurrentResolveComplexSingleton_ConstructsEachOnce(t *testing.T) {
+	c := gotainer.NewContainer()
+	var leafConstructions atomic.Int32
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, func() (*TierTwoTypeOne, error) {
+		leafConstructions.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return &TierTwoTypeOne{data: 1}, nil
+	})
+	gotainer.MustRegisterSingleton[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterTransient[TierOneType](c, NewTierOneType)
+	gotainer.MustRegisterTransient[TierZeroType](c, NewTierZeroType)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := gotainer.Resolve[TierZeroType](c); err != nil {
+				t.Error(err)
+			}
+			if _, err := gotainer.Resolve[TierTwoTypeOne](c); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if leafConstructions.Load() != 1 {
+		t.Errorf("expected singleton dependency to be constructed once, was constructed %d times", leafConstructions.Load())
+	}
+}
+
+func TestContainer_ConcurrentRegisterAndResolve_DoesNotRace(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+		gotainer.MustRegisterSingleton[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+		gotainer.MustRegisterTransient[InterfaceType](c, NewInterfaceableType)
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if _, err := gotainer.Resolve[SimpleStruct](c); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+func TestContainer_RegisterSelfReferencingType_ReturnsCircularDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[SelfReferencingType](c, NewSelfReferencingType)
+	if err == nil {
+		t.Error("expected error when registering a type which depends on itself")
+		return
+	}
+
+	cycleErr := &gotainer.CircularDependencyError{}
+	if !errors.As(err, &cycleErr) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+		return
+	}
+
+	expected := []string{"SelfReferencingType", "SelfReferencingType"}
+	if !slices.Equal(cycleErr.Chain, expected) {
+		t.Errorf("expected chain %v, got %v", expected, cycleErr.Chain)
+	}
+}
+
+func TestContainer_ResolveUnregisteredType_ReturnsTypeNotRegisteredError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+
+	_, err := gotainer.Resolve[SimpleStruct](c)
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected error to be TypeNotRegisteredError, got %v", err)
+		return
+	}
+
+	if notRegisteredErr.TypeName != "github.com/BlindGarret/gotainer_test.SimpleStruct" {
+		t.Errorf("expected error to name the package qualified type, got %s", notRegisteredErr.TypeName)
+	}
+	if len(notRegisteredErr.Suggestions) != 0 {
+		t.Errorf("expected no suggestions for an unrelated type, got %v", notRegisteredErr.Suggestions)
+	}
+}
+
+func TestContainer_ResolveInterfaceUnregisteredType_ReturnsTypeNotRegisteredError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	_, err := gotainer.ResolveInterface[InterfaceType](c)
+
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected error to be TypeNotRegisteredError, got %v", err)
+	}
+}
+
+func TestContainer_ResolveMisspelledType_SuggestsSimilarRegistrations(t *testing.T) {
+	type SimpleStrukt struct{}
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+
+	_, err := gotainer.Resolve[SimpleStrukt](c)
+
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected error to be TypeNotRegisteredError, got %v", err)
+		return
+	}
+
+	expected := []string{"github.com/BlindGarret/gotainer_test.SimpleStruct"}
+	if !slices.Equal(notRegisteredErr.Suggestions, expected) {
+		t.Errorf("expected suggestions %v, got %v", expected, notRegisteredErr.Suggestions)
+	}
+}
+
+func TestContainer_ResolvePointerOfRegisteredType_SuggestsRegisteredType(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+
+	_, err := gotainer.Resolve[*SimpleStruct](c)
+
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected error to be TypeNotRegisteredError, got %v", err)
+		return
+	}
+
+	expected := []string{"github.com/BlindGarret/gotainer_test.SimpleStruct"}
+	if !slices.Equal(notRegisteredErr.Suggestions, expected) {
+		t.Errorf("expected suggestions %v, got %v", expected, notRegisteredErr.Suggestions)
+	}
+}
+
+func TestContainer_ResolveErrConstructorInMixedLifetimeType_ReturnsResolutionPath(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[TierTwoTypeTwo](c, NewErroringTierTwoTypeTwo)
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+	gotainer.MustRegisterTransient[TierOneType](c, NewTierOneType)
+	gotainer.MustRegisterSingleton[TierZeroType](c, NewTierZeroType)
+
+	_, err := gotainer.Resolve[TierZeroType](c)
+
+	resolutionErr := &gotainer.ResolutionError{}
+	if !errors.As(err, &resolutionErr) {
+		t.Errorf("expected error to be ResolutionError, got %v", err)
+		return
+	}
+
+	expected := []gotainer.ResolutionStep{
+		{TypeName: "TierZeroType", Lifetime: gotainer.LifetimeSingleton},
+		{TypeName: "TierOneType", Lifetime: gotainer.LifetimeTransient},
+		{TypeName: "TierTwoTypeTwo", Lifetime: gotainer.LifetimeSingleton},
+	}
+	if !slices.Equal(resolutionErr.Path, expected) {
+		t.Errorf("expected path %v, got %v", expected, resolutionErr.Path)
+	}
+	if !errors.Is(err, TierTwoTypeTwoError) {
+		t.Error("expected error to wrap TierTwoTypeTwoError")
+	}
+
+	expectedMsg := "unable to resolve TierZeroType (singleton) -> TierOneType (transient) -> TierTwoTypeTwo (singleton): tier two type two error"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected message %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+func TestContainer_ResolveErrConstructorDirectly_ReturnsSingleStepPath(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[TierTwoTypeTwo](c, NewErroringTierTwoTypeTwo)
+
+	_, err := gotainer.Resolve[TierTwoTypeTwo](c)
+
+	resolutionErr := &gotainer.ResolutionError{}
+	if !errors.As(err, &resolutionErr) {
+		t.Errorf("expected error to be ResolutionError, got %v", err)
+		return
+	}
+
+	expected := []gotainer.ResolutionStep{{TypeName: "TierTwoTypeTwo", Lifetime: gotainer.LifetimeTransient}}
+	if !slices.Equal(resolutionErr.Path, expected) {
+		t.Errorf("expected path %v, got %v", expected, resolutionErr.Path)
+	}
+}
+
+func TestContainer_ResolveMissingDeferredDependency_ReturnsResolutionPath(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterTransient[TierOneType](c, NewTierOneType)
+	gotainer.MustRegisterTransient[TierTwoTypeOne](c, NewTierTwoTypeOne)
+
+	_, err := gotainer.Resolve[TierOneType](c)
+
+	resolutionErr := &gotainer.ResolutionError{}
+	if !errors.As(err, &resolutionErr) {
+		t.Errorf("expected error to be ResolutionError, got %v", err)
+		return
+	}
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected error to wrap TypeNotRegisteredError, got %v", err)
+		return
+	}
+
+	expected := []gotainer.ResolutionStep{{TypeName: "TierOneType", Lifetime: gotainer.LifetimeTransient}}
+	if !slices.Equal(resolutionErr.Path, expected) {
+		t.Errorf("expected path %v, got %v", expected, resolutionErr.Path)
+	}
+}
+
+func TestContainer_RegisterCtorWithInterfaceParam_InjectsInterface(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[InterfaceType](c, NewInterfaceableType)
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+	gotainer.MustRegisterTransient[InterfaceConsumer](c, NewInterfaceConsumer)
+
+	consumer, err := gotainer.Resolve[InterfaceConsumer](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, ok := consumer.dep.(*InterfaceableType); !ok {
+		t.Errorf("expected injected interface to hold *InterfaceableType, got %T", consumer.dep)
+		return
+	}
+	consumer.dep.DoThing()
+	if consumer.simple != gotainer.MustResolve[SimpleStruct](c) {
+		t.Error("expected pointer dependency alongside the interface to be the singleton")
+	}
+	if consumer.dep != gotainer.MustResolveInterface[InterfaceType](c) {
+		t.Error("expected injected interface to be the singleton")
+	}
+}
+
+func TestContainer_ResolveNilInterface_ReturnsNil(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[InterfaceType](c, NewNilInterfaceType)
+
+	resolved, err := gotainer.ResolveInterface[InterfaceType](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resolved != nil {
+		t.Errorf("expected nil interface, got %v", resolved)
+	}
+}
+
+func TestContainer_ResolveInterfaceWithResolve_ReturnsPointerToInterface(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[InterfaceType](c, NewInterfaceableType)
+
+	resolved, err := gotainer.Resolve[InterfaceType](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if *resolved != gotainer.MustResolveInterface[InterfaceType](c) {
+		t.Error("expected pointer to hold the registered interface")
+	}
+}
+
+func TestContainer_ResolveSingletonAfterGC_KeepsInstanceAlive(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[TierTwoTypeTwo](c, func() (*TierTwoTypeTwo, error) {
+		return &TierTwoTypeTwo{data: strings.Repeat("two", 1024)}, nil
+	})
+	gotainer.MustRegisterSingleton[InterfaceType](c, NewInterfaceableType)
+	gotainer.MustResolve[TierTwoTypeTwo](c)
+	gotainer.MustResolveInterface[InterfaceType](c)
+
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+		// churn the heap so any memory the container failed to keep alive gets reused
+		_ = make([]byte, 1<<20)
+	}
+
+	resolved := gotainer.MustResolve[TierTwoTypeTwo](c)
+	if resolved.data != strings.Repeat("two", 1024) {
+		t.Error("expected singleton to survive garbage collection intact")
+	}
+	if _, ok := gotainer.MustResolveInterface[InterfaceType](c).(*InterfaceableType); !ok {
+		t.Error("expected interface singleton to survive garbage collection intact")
+	}
+}
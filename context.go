@@ -0,0 +1,35 @@
+package gotainer
+
+import (
+	"context"
+	"reflect"
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// ResolveContext resolves T with ctx, which is passed to every ctor taking a context.Context as its
+// first parameter. Once ctx is done no further ctors are called and its error is returned.
+func ResolveContext[T any](ctx context.Context, resolver Resolver) (*T, error) {
+	return resolvePointer[T](ctx, resolver, keyOf[T](""))
+}
+
+func MustResolveContext[T any](ctx context.Context, resolver Resolver) *T {
+	res, err := ResolveContext[T](ctx, resolver)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ResolveInterfaceContext resolves the interface T with ctx, see ResolveContext.
+func ResolveInterfaceContext[T any](ctx context.Context, resolver Resolver) (T, error) {
+	return resolveInterface[T](ctx, resolver, keyOf[T](""))
+}
+
+func MustResolveInterfaceContext[T any](ctx context.Context, resolver Resolver) T {
+	res, err := ResolveInterfaceContext[T](ctx, resolver)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
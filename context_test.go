@@ -0,0 +1,153 @@
+package gotainer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestResolveContext_CtorTakingContext_ReceivesResolveContext(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[RequestInfo](c, NewRequestInfo)
+	ctx := context.WithValue(context.Background(), contextKey{}, "request-1")
+
+	info, err := gotainer.ResolveContext[RequestInfo](ctx, c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if info.requestID != "request-1" {
+		t.Errorf("expected ctor to receive the resolve context, got request id %v", info.requestID)
+	}
+}
+
+func TestResolve_CtorTakingContext_ReceivesBackgroundContext(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[RequestInfo](c, NewRequestInfo)
+
+	info, err := gotainer.Resolve[RequestInfo](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if info.requestID != nil {
+		t.Errorf("expected ctor to receive an empty context, got request id %v", info.requestID)
+	}
+}
+
+func TestResolveContext_DependencyTakingContext_ReceivesResolveContext(t *testing.T) {
+	c := gotainer.NewContainer()
+	calls := 0
+	gotainer.MustRegisterTransient[RequestInfo](c, NewRequestInfo)
+	gotainer.MustRegisterTransient[RequestHandler](c, NewRequestHandlerFactory(&calls))
+	ctx := context.WithValue(context.Background(), contextKey{}, "request-2")
+
+	handler, err := gotainer.ResolveContext[RequestHandler](ctx, c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if handler.info.requestID != "request-2" {
+		t.Errorf("expected dependency to receive the resolve context, got request id %v", handler.info.requestID)
+	}
+}
+
+func TestResolveContext_CancelledContext_ReturnsErrorWithoutConstructing(t *testing.T) {
+	c := gotainer.NewContainer()
+	calls := 0
+	gotainer.MustRegisterTransient[RequestInfo](c, NewRequestInfo)
+	gotainer.MustRegisterTransient[RequestHandler](c, NewRequestHandlerFactory(&calls))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := gotainer.ResolveContext[RequestHandler](ctx, c)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap context.Canceled, got %v", err)
+		return
+	}
+	var resolutionErr *gotainer.ResolutionError
+	if !errors.As(err, &resolutionErr) {
+		t.Errorf("expected error to be a ResolutionError, got %T", err)
+		return
+	}
+	if calls != 0 {
+		t.Errorf("expected no ctor to be called, got %d calls", calls)
+	}
+}
+
+func TestResolveContext_CancelledWhileResolving_StopsRemainingCtors(t *testing.T) {
+	c := gotainer.NewContainer()
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gotainer.MustRegisterTransient[RequestInfo](c, func(ctx context.Context) (*RequestInfo, error) {
+		cancel()
+		return &RequestInfo{}, nil
+	})
+	gotainer.MustRegisterTransient[RequestHandler](c, NewRequestHandlerFactory(&calls))
+
+	_, err := gotainer.ResolveContext[RequestHandler](ctx, c)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap context.Canceled, got %v", err)
+		return
+	}
+	if calls != 0 {
+		t.Errorf("expected dependent ctor not to be called, got %d calls", calls)
+	}
+}
+
+func TestResolveContext_CancelledContextWithBuiltSingleton_ReturnsSingleton(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[RequestInfo](c, NewRequestInfo)
+	expected := gotainer.MustResolve[RequestInfo](c)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	info, err := gotainer.ResolveContext[RequestInfo](ctx, c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if info != expected {
+		t.Error("expected the already built singleton to be returned")
+	}
+}
+
+func TestRegister_CtorWithContextNotFirst_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[SimpleStruct](c, NewSimpleStruct)
+
+	err := gotainer.RegisterTransient[RequestInfo](c, BadCtorForRequestInfoContextNotFirst)
+
+	var mismatchErr *gotainer.ConstructorMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Errorf("expected ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestInvokeContext_FnTakingContext_ReceivesContext(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[RequestInfo](c, NewRequestInfo)
+	ctx := context.WithValue(context.Background(), contextKey{}, "request-3")
+
+	err := gotainer.InvokeContext(ctx, c, func(fnCtx context.Context, info *RequestInfo) {
+		if fnCtx.Value(contextKey{}) != "request-3" {
+			t.Error("expected fn to receive the invoke context")
+		}
+		if info.requestID != "request-3" {
+			t.Errorf("expected ctor to receive the invoke context, got request id %v", info.requestID)
+		}
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
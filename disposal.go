@@ -0,0 +1,132 @@
+package gotainer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// disposer returns the teardown for a constructed instance, or nil if it needs none.
+type disposer func(instance reflect.Value) func() error
+
+// disposables records teardowns in construction order so they can be run in reverse.
+type disposables struct {
+	mu       sync.Mutex
+	closed   bool
+	cleanups []func() error
+}
+
+// add records cleanup to run on close. Once closed nothing would run it later, so cleanup runs
+// straight away and a ContainerClosedError is returned.
+func (d *disposables) add(cleanup func() error) error {
+	if cleanup == nil {
+		return nil
+	}
+	d.mu.Lock()
+	if !d.closed {
+		d.cleanups = append(d.cleanups, cleanup)
+		d.mu.Unlock()
+		return nil
+	}
+	d.mu.Unlock()
+	return errors.Join(NewContainerClosedError(), cleanup())
+}
+
+// addWith records the cleanup returned by fn, which runs under the lock so that nothing can be
+// closed meanwhile. Once closed fn is not run and a ContainerClosedError is returned.
+func (d *disposables) addWith(fn func() (func() error, error)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return NewContainerClosedError()
+	}
+	cleanup, err := fn()
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		d.cleanups = append(d.cleanups, cleanup)
+	}
+	return nil
+}
+
+func (d *disposables) isClosed() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.closed
+}
+
+// close runs every recorded teardown in reverse construction order, stopping early if ctx is
+// done. Teardowns which did not run are kept for a later close. All errors are joined together.
+func (d *disposables) close(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	cleanups := d.cleanups
+	d.cleanups = nil
+	d.mu.Unlock()
+
+	var errs []error
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			d.mu.Lock()
+			d.cleanups = append(cleanups[:i+1:i+1], d.cleanups...)
+			d.mu.Unlock()
+			errs = append(errs, err)
+			break
+		}
+		if err := cleanups[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close tears down every constructed singleton in reverse construction order, using the cleanup
+// registered with WithCleanup or io.Closer. Further resolves return a ContainerClosedError. If ctx
+// is done before every teardown has run, calling Close again runs the rest.
+// Scopes are not closed by their Container and should be closed individually.
+func (c *Container) Close(ctx context.Context) error {
+	return c.disposables.close(ctx)
+}
+
+// Close tears down every scoped instance constructed by the scope in reverse construction order,
+// further resolves from the scope return a ContainerClosedError.
+func (s *Scope) Close(ctx context.Context) error {
+	return s.disposables.close(ctx)
+}
+
+func newDisposer(fnType reflect.Type, options *registrationOptions) disposer {
+	return func(instance reflect.Value) func() error {
+		// ctors which return a cleanup own the teardown of what they construct
+		if instance.IsNil() || fnType.NumOut() == 3 || options.unowned {
+			return nil
+		}
+		value := instance.Interface()
+		if fnType.Out(0).Kind() == reflect.Struct {
+			// result objects returned by value are stored as a pointer, cleanups receive them as returned
+			value = instance.Elem().Interface()
+		}
+		if options.cleanup != nil {
+			return func() error {
+				return options.cleanup(value)
+			}
+		}
+		if closer, ok := value.(io.Closer); ok {
+			return closer.Close
+		}
+		if instance.Kind() == reflect.Ptr && isResultObject(instance.Type().Elem()) {
+			return closeResultFields(instance.Elem())
+		}
+		return nil
+	}
+}
+
+// ownerOf returns where teardowns recorded during a resolve belong, the scope if there is one.
+func ownerOf(container *Container, scope *Scope) *disposables {
+	if scope != nil {
+		return &scope.disposables
+	}
+	return &container.disposables
+}
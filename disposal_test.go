@@ -0,0 +1,379 @@
+package gotainer_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestContainer_CloseWithClosableSingletons_ClosesInReverseConstructionOrder(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	gotainer.MustRegisterSingleton[ClosableLeaf](c, NewClosableLeaf)
+	gotainer.MustRegisterSingleton[ClosableRoot](c, NewClosableRoot)
+
+	root := gotainer.MustResolve[ClosableRoot](c)
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{"ClosableRoot", "ClosableLeaf"}
+	if !slices.Equal(root.leaf.log.closed, expected) {
+		t.Errorf("expected close order %v, got %v", expected, root.leaf.log.closed)
+	}
+}
+
+func TestContainer_CloseWithUnresolvedSingletons_DoesNotConstructThem(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	constructed := false
+	gotainer.MustRegisterSingleton[ClosableLeaf](c, func(log *CloseLog) (*ClosableLeaf, error) {
+		constructed = true
+		return NewClosableLeaf(log)
+	})
+
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if constructed {
+		t.Error("expected close not to construct unresolved singletons")
+	}
+}
+
+func TestContainer_CloseWithCleanupOption_UsesCleanup(t *testing.T) {
+	c := gotainer.NewContainer()
+	var cleaned *SimpleStruct
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct, gotainer.WithCleanup(func(s *SimpleStruct) error {
+		cleaned = s
+		return nil
+	}))
+
+	resolved := gotainer.MustResolve[SimpleStruct](c)
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if cleaned != resolved {
+		t.Error("expected cleanup to be called with the resolved singleton")
+	}
+}
+
+func TestContainer_RegisterWithMismatchedCleanup_ReturnsError(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[SimpleStruct](c, NewSimpleStruct, gotainer.WithCleanup(func(s *TierTwoTypeOne) error {
+		return nil
+	}))
+
+	ctorErr := &gotainer.ConstructorMismatchError{}
+	if !errors.As(err, &ctorErr) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestContainer_CloseWithFailingClosers_AggregatesErrors(t *testing.T) {
+	leafErr := errors.New("leaf close error")
+	rootErr := errors.New("root close error")
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	gotainer.MustRegisterSingleton[ClosableLeaf](c, func(log *CloseLog) (*ClosableLeaf, error) {
+		return &ClosableLeaf{log: log, err: leafErr}, nil
+	})
+	gotainer.MustRegisterSingleton[ClosableRoot](c, func(leaf *ClosableLeaf) (*ClosableRoot, error) {
+		return &ClosableRoot{leaf: leaf, err: rootErr}, nil
+	})
+	root := gotainer.MustResolve[ClosableRoot](c)
+
+	err := c.Close(context.Background())
+	if !errors.Is(err, leafErr) || !errors.Is(err, rootErr) {
+		t.Errorf("expected error to contain both close errors, got %v", err)
+	}
+	if len(root.leaf.log.closed) != 2 {
+		t.Errorf("expected every closer to run despite errors, got %v", root.leaf.log.closed)
+	}
+}
+
+func TestContainer_CloseWithCancelledContext_StopsEarly(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	gotainer.MustRegisterSingleton[ClosableLeaf](c, NewClosableLeaf)
+	gotainer.MustRegisterSingleton[ClosableRoot](c, NewClosableRoot)
+	root := gotainer.MustResolve[ClosableRoot](c)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.Close(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to be context.Canceled, got %v", err)
+		return
+	}
+	if len(root.leaf.log.closed) != 0 {
+		t.Errorf("expected no closers to run, got %v", root.leaf.log.closed)
+		return
+	}
+
+	err = c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []string{"ClosableRoot", "ClosableLeaf"}
+	if !slices.Equal(root.leaf.log.closed, expected) {
+		t.Errorf("expected the second close to run the remaining closers %v, got %v", expected, root.leaf.log.closed)
+	}
+}
+
+func TestContainer_SingletonConstructedDuringClose_ClosesSingleton(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	log := gotainer.MustResolve[CloseLog](c)
+	gotainer.MustRegisterSingleton[ClosableLeaf](c, func(log *CloseLog) (*ClosableLeaf, error) {
+		// the container is closed while the singleton is being constructed
+		_ = c.Close(context.Background())
+		return NewClosableLeaf(log)
+	})
+
+	_, err := gotainer.Resolve[ClosableLeaf](c)
+
+	closedErr := &gotainer.ContainerClosedError{}
+	if !errors.As(err, &closedErr) {
+		t.Errorf("expected ContainerClosedError, got %v", err)
+		return
+	}
+	if !slices.Equal(log.closed, []string{"ClosableLeaf"}) {
+		t.Errorf("expected the singleton to be closed, got %v", log.closed)
+	}
+}
+
+func TestScope_ScopedConstructedDuringClose_ClosesScoped(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	log := gotainer.MustResolve[CloseLog](c)
+	scope := c.NewScope()
+	gotainer.MustRegisterScoped[ClosableLeaf](c, func(log *CloseLog) (*ClosableLeaf, error) {
+		// the scope is closed while the scoped instance is being constructed
+		_ = scope.Close(context.Background())
+		return NewClosableLeaf(log)
+	})
+
+	_, err := gotainer.Resolve[ClosableLeaf](scope)
+
+	closedErr := &gotainer.ContainerClosedError{}
+	if !errors.As(err, &closedErr) {
+		t.Errorf("expected ContainerClosedError, got %v", err)
+		return
+	}
+	if !slices.Equal(log.closed, []string{"ClosableLeaf"}) {
+		t.Errorf("expected the scoped instance to be closed, got %v", log.closed)
+	}
+}
+
+func TestContainer_ResolveAfterClose_ReturnsContainerClosedError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+	gotainer.MustRegisterScoped[TierTwoTypeOne](c, NewTierTwoTypeOne)
+	scope := c.NewScope()
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	closedErr := &gotainer.ContainerClosedError{}
+	_, err = gotainer.Resolve[SimpleStruct](c)
+	if !errors.As(err, &closedErr) {
+		t.Errorf("expected error to be ContainerClosedError, got %v", err)
+	}
+	_, err = gotainer.Resolve[TierTwoTypeOne](scope)
+	if !errors.As(err, &closedErr) {
+		t.Errorf("expected error from scope of closed container to be ContainerClosedError, got %v", err)
+	}
+}
+
+func TestScope_CloseWithClosableScoped_ClosesOnlyScopedInstances(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	gotainer.MustRegisterScoped[ClosableLeaf](c, NewClosableLeaf)
+	gotainer.MustRegisterScoped[ClosableRoot](c, NewClosableRoot)
+	scope := c.NewScope()
+	root := gotainer.MustResolve[ClosableRoot](scope)
+
+	err := scope.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{"ClosableRoot", "ClosableLeaf"}
+	if !slices.Equal(root.leaf.log.closed, expected) {
+		t.Errorf("expected close order %v, got %v", expected, root.leaf.log.closed)
+	}
+
+	closedErr := &gotainer.ContainerClosedError{}
+	_, err = gotainer.Resolve[ClosableRoot](scope)
+	if !errors.As(err, &closedErr) {
+		t.Errorf("expected error to be ContainerClosedError, got %v", err)
+	}
+	_, err = gotainer.Resolve[CloseLog](c)
+	if err != nil {
+		t.Errorf("expected root container to remain usable after closing a scope, got %v", err)
+	}
+}
+
+func TestContainer_CloseWithCtorCleanups_RunsInDependencyReverseOrder(t *testing.T) {
+	log := &CloseLog{}
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOneWithCleanup(log))
+	gotainer.MustRegisterSingleton[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterSingleton[TierOneType](c, NewTierOneTypeWithCleanup(log))
+
+	gotainer.MustResolve[TierOneType](c)
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{"TierOneType", "TierTwoTypeOne"}
+	if !slices.Equal(log.closed, expected) {
+		t.Errorf("expected cleanup order %v, got %v", expected, log.closed)
+	}
+}
+
+func TestContainer_CloseWithFailingCtorCleanup_ReturnsError(t *testing.T) {
+	cleanupErr := errors.New("cleanup error")
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, func() (*SimpleStruct, func() error, error) {
+		return &SimpleStruct{data: 1}, func() error { return cleanupErr }, nil
+	})
+	gotainer.MustResolve[SimpleStruct](c)
+
+	err := c.Close(context.Background())
+	if !errors.Is(err, cleanupErr) {
+		t.Errorf("expected error to be cleanup error, got %v", err)
+	}
+}
+
+func TestContainer_CloseWithCtorCleanupForCloser_DoesNotAlsoCallClose(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	gotainer.MustRegisterSingleton[ClosableLeaf](c, func(log *CloseLog) (*ClosableLeaf, func() error, error) {
+		leaf := &ClosableLeaf{log: log}
+		return leaf, leaf.Close, nil
+	})
+	leaf := gotainer.MustResolve[ClosableLeaf](c)
+
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(leaf.log.closed) != 1 {
+		t.Errorf("expected the ctor cleanup to replace io.Closer, closed %v", leaf.log.closed)
+	}
+}
+
+func TestScope_CloseWithTransientCtorCleanups_RunsEachCleanup(t *testing.T) {
+	log := &CloseLog{}
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[TierTwoTypeOne](c, NewTierTwoTypeOneWithCleanup(log))
+	scope := c.NewScope()
+	gotainer.MustResolve[TierTwoTypeOne](scope)
+	gotainer.MustResolve[TierTwoTypeOne](scope)
+
+	err := scope.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(log.closed) != 2 {
+		t.Errorf("expected a cleanup per resolved transient, got %v", log.closed)
+	}
+}
+
+func TestContainer_ResolveTransientWithCtorCleanupFromRoot_ReturnsCleanupScopeRequiredError(t *testing.T) {
+	log := &CloseLog{}
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[TierTwoTypeOne](c, NewTierTwoTypeOneWithCleanup(log))
+
+	_, err := gotainer.Resolve[TierTwoTypeOne](c)
+
+	scopeErr := &gotainer.CleanupScopeRequiredError{}
+	if !errors.As(err, &scopeErr) {
+		t.Errorf("expected error to be CleanupScopeRequiredError, got %v", err)
+	}
+}
+
+func TestContainer_SingletonWithTransientCtorCleanup_RunsCleanupOnClose(t *testing.T) {
+	log := &CloseLog{}
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[TierTwoTypeOne](c, NewTierTwoTypeOneWithCleanup(log))
+	gotainer.MustRegisterTransient[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterSingleton[TierOneType](c, NewTierOneType)
+	gotainer.MustResolve[TierOneType](c)
+
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !slices.Equal(log.closed, []string{"TierTwoTypeOne"}) {
+		t.Errorf("expected the cleanup of the singleton's transient dependency to run, got %v", log.closed)
+	}
+}
+
+func TestContainer_CtorCleanupWithFailingCtor_IsNotRecorded(t *testing.T) {
+	cleaned := false
+	ctorErr := errors.New("ctor error")
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, func() (*SimpleStruct, func(), error) {
+		return nil, func() { cleaned = true }, ctorErr
+	})
+	_, err := gotainer.Resolve[SimpleStruct](c)
+	if !errors.Is(err, ctorErr) {
+		t.Errorf("expected error to be ctor error, got %v", err)
+		return
+	}
+
+	err = c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if cleaned {
+		t.Error("expected cleanup from a failed ctor not to run")
+	}
+}
+
+func TestContainer_RegisterWithBadCleanupReturn_ReturnsError(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[SimpleStruct](c, BadCtorForSimpleStructNonFuncCleanup)
+
+	ctorErr := &gotainer.ConstructorMismatchError{}
+	if !errors.As(err, &ctorErr) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestContainer_RegisterCtorCleanupWithoutCleanup_ReturnsError(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOneWithCleanup(&CloseLog{}), gotainer.WithoutCleanup())
+
+	ctorErr := &gotainer.ConstructorMismatchError{}
+	if !errors.As(err, &ctorErr) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
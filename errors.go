@@ -1,6 +1,9 @@
 package gotainer
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type PrefetchArgumentError struct {
 	ParentTypeName string
@@ -23,7 +26,7 @@ type ConstructorMismatchError struct {
 }
 
 func (e *ConstructorMismatchError) Error() string {
-	return fmt.Sprintf("ctor must return a pointer or interface to the type it is constructing, along with an error: (T*, error) || (Ti, error): %s", e.Reason)
+	return fmt.Sprintf("ctor must return a pointer or interface to the type it is constructing, along with an optional cleanup and an error: (T*, error) || (Ti, error) || (T*, func() error, error): %s", e.Reason)
 }
 
 func NewConstructorMismatchError(reason string) *ConstructorMismatchError {
@@ -31,3 +34,301 @@ func NewConstructorMismatchError(reason string) *ConstructorMismatchError {
 		Reason: reason,
 	}
 }
+
+type DuplicateRegistrationError struct {
+	TypeName string
+}
+
+func (e *DuplicateRegistrationError) Error() string {
+	return fmt.Sprintf("type %s is already registered, each type may only be registered once per container", e.TypeName)
+}
+
+func NewDuplicateRegistrationError(typeName string) *DuplicateRegistrationError {
+	return &DuplicateRegistrationError{
+		TypeName: typeName,
+	}
+}
+
+type ScopeRequiredError struct {
+	TypeName string
+}
+
+func (e *ScopeRequiredError) Error() string {
+	return fmt.Sprintf("type %s is registered as scoped and must be resolved from a scope, use Container.NewScope()", e.TypeName)
+}
+
+func NewScopeRequiredError(typeName string) *ScopeRequiredError {
+	return &ScopeRequiredError{
+		TypeName: typeName,
+	}
+}
+
+type CleanupScopeRequiredError struct {
+	TypeName string
+}
+
+func (e *CleanupScopeRequiredError) Error() string {
+	return fmt.Sprintf("type %s is transient and returns a cleanup, it must be resolved from a scope or by a singleton, use Container.NewScope()", e.TypeName)
+}
+
+func NewCleanupScopeRequiredError(typeName string) *CleanupScopeRequiredError {
+	return &CleanupScopeRequiredError{
+		TypeName: typeName,
+	}
+}
+
+type ScopedDependencyError struct {
+	ParentTypeName string
+	DependencyName string
+}
+
+func (e *ScopedDependencyError) Error() string {
+	return fmt.Sprintf("singleton %s cannot depend on scoped type %s, a singleton would outlive the scope it captured", e.ParentTypeName, e.DependencyName)
+}
+
+func NewScopedDependencyError(parentTypeName, dependencyName string) *ScopedDependencyError {
+	return &ScopedDependencyError{
+		ParentTypeName: parentTypeName,
+		DependencyName: dependencyName,
+	}
+}
+
+type ContainerClosedError struct {
+}
+
+func (e *ContainerClosedError) Error() string {
+	return "unable to resolve from a container or scope which has been closed"
+}
+
+func NewContainerClosedError() *ContainerClosedError {
+	return &ContainerClosedError{}
+}
+
+type InvokeMismatchError struct {
+	Reason string
+}
+
+func (e *InvokeMismatchError) Error() string {
+	return fmt.Sprintf("invoked fn must be a function returning nothing or an error: func(...) || func(...) error: %s", e.Reason)
+}
+
+func NewInvokeMismatchError(reason string) *InvokeMismatchError {
+	return &InvokeMismatchError{
+		Reason: reason,
+	}
+}
+
+type CircularDependencyError struct {
+	Chain []string
+}
+
+func (e *CircularDependencyError) Error() string {
+	return fmt.Sprintf("circular dependency detected: %s", strings.Join(e.Chain, " -> "))
+}
+
+func NewCircularDependencyError(chain []string) *CircularDependencyError {
+	return &CircularDependencyError{
+		Chain: chain,
+	}
+}
+
+type MissingDependencyError struct {
+	ParentTypeName string
+	DependencyName string
+}
+
+func (e *MissingDependencyError) Error() string {
+	return fmt.Sprintf("type %s which is a dependency for %s is not registered", e.DependencyName, e.ParentTypeName)
+}
+
+func NewMissingDependencyError(parentTypeName, dependencyName string) *MissingDependencyError {
+	return &MissingDependencyError{
+		ParentTypeName: parentTypeName,
+		DependencyName: dependencyName,
+	}
+}
+
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("container validation failed with %d errors:\n\t%s", len(e.Errors), strings.Join(messages, "\n\t"))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+func NewValidationError(errs []error) *ValidationError {
+	return &ValidationError{
+		Errors: errs,
+	}
+}
+
+type TypeNotRegisteredError struct {
+	TypeName    string
+	Suggestions []string
+}
+
+func (e *TypeNotRegisteredError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("type %s is not registered", e.TypeName)
+	}
+	return fmt.Sprintf("type %s is not registered, did you mean %s?", e.TypeName, strings.Join(e.Suggestions, " or "))
+}
+
+func NewTypeNotRegisteredError(typeName string, suggestions []string) *TypeNotRegisteredError {
+	return &TypeNotRegisteredError{
+		TypeName:    typeName,
+		Suggestions: suggestions,
+	}
+}
+
+// ResolutionStep is a single type on the path from the resolved type down to the one which failed.
+type ResolutionStep struct {
+	TypeName string
+	Lifetime Lifetime
+}
+
+type ResolutionError struct {
+	Path []ResolutionStep
+	Err  error
+}
+
+func (e *ResolutionError) Error() string {
+	steps := make([]string, len(e.Path))
+	for i, step := range e.Path {
+		steps[i] = fmt.Sprintf("%s (%s)", step.TypeName, step.Lifetime)
+	}
+	return fmt.Sprintf("unable to resolve %s: %v", strings.Join(steps, " -> "), e.Err)
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+func NewResolutionError(path []ResolutionStep, err error) *ResolutionError {
+	return &ResolutionError{
+		Path: path,
+		Err:  err,
+	}
+}
+
+type InvalidBindingError struct {
+	InterfaceName string
+	TypeName      string
+	Reason        string
+}
+
+func (e *InvalidBindingError) Error() string {
+	return fmt.Sprintf("unable to bind %s to %s: %s", e.InterfaceName, e.TypeName, e.Reason)
+}
+
+func NewInvalidBindingError(interfaceName, typeName, reason string) *InvalidBindingError {
+	return &InvalidBindingError{
+		InterfaceName: interfaceName,
+		TypeName:      typeName,
+		Reason:        reason,
+	}
+}
+
+type DuplicateMapKeyError struct {
+	TypeName string
+	Key      string
+}
+
+func (e *DuplicateMapKeyError) Error() string {
+	return fmt.Sprintf("map key %q is already registered for %s, each key may only be registered once per map", e.Key, e.TypeName)
+}
+
+func NewDuplicateMapKeyError(typeName, key string) *DuplicateMapKeyError {
+	return &DuplicateMapKeyError{
+		TypeName: typeName,
+		Key:      key,
+	}
+}
+
+type InvalidPopulateTargetError struct {
+	Reason string
+}
+
+func (e *InvalidPopulateTargetError) Error() string {
+	return fmt.Sprintf("populate target must be a non-nil pointer to a struct whose inject tagged fields can be injected: %s", e.Reason)
+}
+
+func NewInvalidPopulateTargetError(reason string) *InvalidPopulateTargetError {
+	return &InvalidPopulateTargetError{
+		Reason: reason,
+	}
+}
+
+type FieldInjectionError struct {
+	FieldName string
+	Err       error
+}
+
+func (e *FieldInjectionError) Error() string {
+	return fmt.Sprintf("unable to inject field %s: %v", e.FieldName, e.Err)
+}
+
+func (e *FieldInjectionError) Unwrap() error {
+	return e.Err
+}
+
+func NewFieldInjectionError(fieldName string, err error) *FieldInjectionError {
+	return &FieldInjectionError{
+		FieldName: fieldName,
+		Err:       err,
+	}
+}
+
+type PopulateError struct {
+	TypeName string
+	Errors   []error
+}
+
+func (e *PopulateError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("populating %s failed with %d errors:\n\t%s", e.TypeName, len(e.Errors), strings.Join(messages, "\n\t"))
+}
+
+func (e *PopulateError) Unwrap() []error {
+	return e.Errors
+}
+
+func NewPopulateError(typeName string, errs []error) *PopulateError {
+	return &PopulateError{
+		TypeName: typeName,
+		Errors:   errs,
+	}
+}
+
+type StartError struct {
+	Errors []error
+}
+
+func (e *StartError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("container start failed with %d errors:\n\t%s", len(e.Errors), strings.Join(messages, "\n\t"))
+}
+
+func (e *StartError) Unwrap() []error {
+	return e.Errors
+}
+
+func NewStartError(errs []error) *StartError {
+	return &StartError{
+		Errors: errs,
+	}
+}
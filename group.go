@@ -0,0 +1,201 @@
+package gotainer
+
+import (
+	"context"
+	"reflect"
+	"slices"
+)
+
+// RegisterTransientGroup adds a transient T to the group of T, ctors taking a []T, or []*T for
+// struct types, receive every member of the group in registration order.
+func RegisterTransientGroup[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) error {
+	return registerGroupMember(container, typeOf[T](), membership{group: groupKey(typeOf[T]())}, ctor, LifetimeTransient, opts)
+}
+
+// RegisterScopedGroup adds a scoped T to the group of T.
+func RegisterScopedGroup[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) error {
+	return registerGroupMember(container, typeOf[T](), membership{group: groupKey(typeOf[T]())}, ctor, LifetimeScoped, opts)
+}
+
+// RegisterSingletonGroup adds a singleton T to the group of T.
+func RegisterSingletonGroup[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) error {
+	return registerGroupMember(container, typeOf[T](), membership{group: groupKey(typeOf[T]())}, ctor, LifetimeSingleton, opts)
+}
+
+func MustRegisterTransientGroup[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) {
+	err := RegisterTransientGroup[T, Fn](container, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterScopedGroup[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) {
+	err := RegisterScopedGroup[T, Fn](container, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterSingletonGroup[T any, Fn any](container *Container, ctor Fn, opts ...RegisterOption) {
+	err := RegisterSingletonGroup[T, Fn](container, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// InGroup adds the type being registered to the group of T as well as registering it as usual.
+// T must be the registered type or an interface it implements.
+func InGroup[T any]() RegisterOption {
+	return inGroup(membership{group: groupKey(typeOf[T]())})
+}
+
+// membership adds a registration to a group, mapKey is only used by map groups.
+type membership struct {
+	group  key
+	mapKey string
+}
+
+func inGroup(m membership) RegisterOption {
+	return func(options *registrationOptions) {
+		options.groups = append(options.groups, m)
+	}
+}
+
+// ResolveAll resolves every member of the group of T in registration order, an empty group
+// resolves to an empty slice.
+func ResolveAll[T any](resolver Resolver) ([]*T, error) {
+	group := groupKey(typeOf[T]())
+	val, err := resolver.resolve(context.Background(), group)
+	if err != nil {
+		return nil, err
+	}
+	if group.t.Elem().Kind() == reflect.Interface {
+		// interface members are held as the interface itself, hand back pointers to copies of them
+		res := make([]*T, val.Len())
+		for i := range res {
+			member := valueAs[T](val.Index(i))
+			res[i] = &member
+		}
+		return res, nil
+	}
+	return valueAs[[]*T](val), nil
+}
+
+func MustResolveAll[T any](resolver Resolver) []*T {
+	res, err := ResolveAll[T](resolver)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ResolveAllInterface resolves every member of the group of the interface T in registration order.
+func ResolveAllInterface[T any](resolver Resolver) ([]T, error) {
+	val, err := resolver.resolve(context.Background(), groupKey(typeOf[T]()))
+	if err != nil {
+		return nil, err
+	}
+	return valueAs[[]T](val), nil
+}
+
+func MustResolveAllInterface[T any](resolver Resolver) []T {
+	res, err := ResolveAllInterface[T](resolver)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// groupKey returns the key of the group of elemType, which resolves to a slice of the injected type.
+func groupKey(elemType reflect.Type) key {
+	return key{t: reflect.SliceOf(injectedType(elemType)), group: true}
+}
+
+func registerGroupMember(container *Container, t reflect.Type, m membership, ctor any, lifetime Lifetime, opts []RegisterOption) error {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	k := key{t: t, member: nextMember(container, t)}
+	return registerLocked(container, k, ctor, lifetime, append(opts[:len(opts):len(opts)], inGroup(m)))
+}
+
+// nextMember numbers anonymous members so that any number of them can be registered, slice and
+// map groups of t share the numbering so their members never collide. Callers must hold the
+// container lock.
+func nextMember(container *Container, t reflect.Type) int {
+	return len(dependenciesOf(container, groupKey(t))) + len(dependenciesOf(container, mapGroupKey(t))) + 1
+}
+
+// testGroupMember checks member can be added to the group, callers must hold the container lock.
+func testGroupMember(container *Container, m membership, member key, deps []dependency) error {
+	group := m.group
+	elemType := group.t.Elem()
+	if !injectedType(member.t).AssignableTo(elemType) {
+		return NewInvalidBindingError(group.String(), member.String(), "type cannot be added to the group")
+	}
+
+	if reg, ok := container.registrations[group]; ok && group.t.Kind() == reflect.Map && slices.Contains(reg.mapKeys, m.mapKey) {
+		return NewDuplicateMapKeyError(group.String(), m.mapKey)
+	}
+
+	if container.deferValidation {
+		return nil
+	}
+	// the group may already be a dependency of the member, adding it would close a cycle
+	if cycle := findCycle(container, group, deps); cycle != nil {
+		return newCircularDependencyError(append([]key{group, member}, cycle[1:]...))
+	}
+	return nil
+}
+
+// addGroupMember appends member to the group, callers must hold the container lock. Groups hold no
+// instances of their own, every member follows its own lifetime so groups are treated as transient.
+func addGroupMember(container *Container, m membership, member key) {
+	reg, ok := container.registrations[m.group]
+	if !ok {
+		reg = &registration{lifetime: LifetimeTransient}
+		reg.ctor = wrapGroupCtor(container, m.group, reg)
+		container.registrations[m.group] = reg
+		container.order = append(container.order, m.group)
+	}
+	reg.deps = append(reg.deps, dependency{key: member})
+	if m.group.t.Kind() == reflect.Map {
+		reg.mapKeys = append(reg.mapKeys, m.mapKey)
+	}
+}
+
+func wrapGroupCtor(container *Container, group key, reg *registration) ctorFunc {
+	return func(res *resolution) (reflect.Value, error) {
+		// members may still be added while resolving, only those present now are resolved
+		container.mu.RLock()
+		members, mapKeys := reg.deps, reg.mapKeys
+		container.mu.RUnlock()
+
+		isMap := group.t.Kind() == reflect.Map
+		var vals reflect.Value
+		if isMap {
+			vals = reflect.MakeMapWithSize(group.t, len(members))
+		} else {
+			vals = reflect.MakeSlice(group.t, len(members), len(members))
+		}
+		for i, member := range members {
+			val, err := resolveNoReflect(container, member.key, res)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if isMap {
+				vals.SetMapIndex(reflect.ValueOf(mapKeys[i]), val)
+			} else {
+				vals.Index(i).Set(val)
+			}
+		}
+		return vals, nil
+	}
+}
+
+// emptyGroup returns the value of a group with no members.
+func emptyGroup(group key) reflect.Value {
+	if group.t.Kind() == reflect.Map {
+		return reflect.MakeMap(group.t)
+	}
+	return reflect.MakeSlice(group.t, 0, 0)
+}
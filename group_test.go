@@ -0,0 +1,130 @@
+package gotainer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestRegisterGroup_SliceParam_ReceivesMembersInRegistrationOrder(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[HealthReporter](c, NewHealthReporter)
+	gotainer.MustRegisterSingletonGroup[HealthCheck](c, NewHealthCheckFactory("cache"))
+	err := gotainer.RegisterTransientGroup[HealthCheck](c, NewHealthCheckFactory("queue"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	reporter := gotainer.MustResolve[HealthReporter](c)
+
+	if len(reporter.checks) != 2 {
+		t.Errorf("expected 2 checks, got %d", len(reporter.checks))
+		return
+	}
+	if reporter.checks[0].(*NamedHealthCheck).name != "cache" || reporter.checks[1].(*NamedHealthCheck).name != "queue" {
+		t.Error("expected checks in registration order")
+	}
+}
+
+func TestRegisterGroup_MemberLifetimes_AreRespected(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonGroup[HealthCheck](c, NewHealthCheckFactory("cache"))
+	gotainer.MustRegisterTransientGroup[HealthCheck](c, NewHealthCheckFactory("queue"))
+
+	first := gotainer.MustResolveAllInterface[HealthCheck](c)
+	second := gotainer.MustResolveAllInterface[HealthCheck](c)
+
+	if first[0] != second[0] {
+		t.Error("expected singleton member to be shared")
+	}
+	if first[1] == second[1] {
+		t.Error("expected transient member to be constructed per resolve")
+	}
+}
+
+func TestResolveAll_EmptyGroup_ReturnsEmptySlice(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	checks, err := gotainer.ResolveAllInterface[HealthCheck](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(checks) != 0 {
+		t.Errorf("expected no checks, got %d", len(checks))
+	}
+}
+
+func TestResolveAll_StructGroup_ReturnsPointers(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonGroup[Database](c, NewDatabaseFactory("primary-dsn"))
+	gotainer.MustRegisterSingletonGroup[Database](c, NewDatabaseFactory("replica-dsn"))
+
+	dbs, err := gotainer.ResolveAll[Database](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(dbs) != 2 || dbs[0].dsn != "primary-dsn" || dbs[1].dsn != "replica-dsn" {
+		t.Errorf("expected both databases in order, got %v", dbs)
+	}
+}
+
+func TestInGroup_ConcreteRegistration_JoinsInterfaceGroup(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonGroup[HealthCheck](c, NewHealthCheckFactory("cache"))
+	err := gotainer.RegisterSingleton[DatabaseHealthCheck](c, NewDatabaseHealthCheck, gotainer.InGroup[HealthCheck]())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	checks := gotainer.MustResolveAllInterface[HealthCheck](c)
+
+	if len(checks) != 2 {
+		t.Errorf("expected 2 checks, got %d", len(checks))
+		return
+	}
+	if checks[1] != HealthCheck(gotainer.MustResolve[DatabaseHealthCheck](c)) {
+		t.Error("expected group member to be the registered singleton")
+	}
+}
+
+func TestInGroup_NotImplemented_ReturnsInvalidBindingError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterSingleton[SimpleStruct](c, NewSimpleStruct, gotainer.InGroup[HealthCheck]())
+
+	if !errors.As(err, new(*gotainer.InvalidBindingError)) {
+		t.Errorf("expected error to be InvalidBindingError, got %v", err)
+		return
+	}
+	if _, err := gotainer.Resolve[SimpleStruct](c); err == nil {
+		t.Error("expected failed registration not to be registered")
+	}
+}
+
+func TestRegisterGroup_MemberDependingOnGroup_ReturnsCircularDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[HealthReporter](c, NewHealthReporter)
+
+	err := gotainer.RegisterTransientGroup[HealthCheck](c, NewHealthCheckNeedingReporter)
+
+	if !errors.As(err, new(*gotainer.CircularDependencyError)) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+	}
+}
+
+func TestWithParamNames_GroupParam_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterTransient[HealthReporter](c, NewHealthReporter, gotainer.WithParamNames("checks"))
+
+	if !errors.As(err, new(*gotainer.ConstructorMismatchError)) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package gotainer
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var inType = reflect.TypeOf(In{})
+
+// In is embedded in a struct to make it a parameter object. A ctor parameter of a parameter object
+// has each of its exported fields injected as if it were a parameter of its own. Fields may be
+// tagged `inject:"name=replica"` to resolve a named registration, `inject:"optional"` to be left
+// as the zero value when not registered, or `inject:"group"` for slice and map fields receiving a
+// group, options are comma separated.
+type In struct{}
+
+func isParamObject(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && field.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// isObjectField reports whether a field of a parameter object is injected.
+func isObjectField(field reflect.StructField) bool {
+	return field.IsExported() && !(field.Anonymous && field.Type == inType)
+}
+
+// objectParams describes each injected field of the parameter object t, which must already have
+// been checked by findInvalidField.
+func objectParams(t reflect.Type) []param {
+	var fields []param
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isObjectField(field) {
+			continue
+		}
+		p := taggedParam(field)
+		p.field = i
+		fields = append(fields, p)
+	}
+	return fields
+}
+
+// taggedParam describes a field injected according to its inject tag, which must be valid.
+func taggedParam(field reflect.StructField) param {
+	tag, _ := parseInjectTag(field.Tag.Get("inject"))
+	p := newParam(field.Type, tag.name)
+	p.dep.optional = p.dep.optional || tag.optional
+	return p
+}
+
+// findInvalidField returns why a field of the parameter object t can never be injected.
+func findInvalidField(t reflect.Type) (string, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isObjectField(field) {
+			continue
+		}
+		if reason, ok := invalidFieldReason(field); ok {
+			return fmt.Sprintf("field %s of %s %s", field.Name, typeName(t), reason), true
+		}
+	}
+	return "", false
+}
+
+// invalidFieldReason returns why a field can never be injected according to its inject tag.
+func invalidFieldReason(field reflect.StructField) (string, bool) {
+	tag, err := parseInjectTag(field.Tag.Get("inject"))
+	switch {
+	case err != nil:
+		return fmt.Sprintf("has an invalid inject tag: %v", err), true
+	case isParamObject(field.Type):
+		if reason, ok := findInvalidField(field.Type); ok {
+			return "is a parameter object whose " + reason, true
+		}
+	case !isValidParam(field.Type):
+		return invalidParamReason, true
+	case tag.group && !isGroupParam(field.Type):
+		return "is tagged as a group but is not a slice or map", true
+	case tag.name != "" && isGroupParam(field.Type):
+		return "receives a group and cannot be resolved by name", true
+	}
+	return "", false
+}
@@ -0,0 +1,115 @@
+package gotainer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func registerReportDeps(c *gotainer.Container) {
+	gotainer.MustRegisterSingletonNamed[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+	gotainer.MustRegisterSingletonNamed[Database](c, "replica", NewDatabaseFactory("replica-dsn"))
+	gotainer.MustRegisterSingletonGroup[HealthCheck](c, NewHealthCheckFactory("cache"))
+	gotainer.MustRegisterSingletonGroup[HealthCheck](c, NewHealthCheckFactory("queue"))
+}
+
+func TestIn_ParamObject_InjectsEachField(t *testing.T) {
+	c := gotainer.NewContainer()
+	registerReportDeps(c)
+	gotainer.MustRegisterSingleton[MetricsExporter](c, NewCountingMetricsExporter)
+	gotainer.MustRegisterSingleton[PostgresStore](c, NewPostgresStore, gotainer.As[Store]())
+	err := gotainer.RegisterTransient[DetailedReportService](c, NewDetailedReportService)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	service := gotainer.MustResolve[DetailedReportService](c)
+
+	deps := service.deps
+	if deps.Primary.dsn != "primary-dsn" || deps.Replica.dsn != "replica-dsn" {
+		t.Errorf("expected named fields to be injected by name, got %q and %q", deps.Primary.dsn, deps.Replica.dsn)
+	}
+	if len(deps.Checks) != 2 {
+		t.Errorf("expected group field to receive 2 checks, got %d", len(deps.Checks))
+	}
+	if deps.Metrics != gotainer.MustResolveInterface[MetricsExporter](c) {
+		t.Error("expected optional field to be injected when registered")
+	}
+	if !deps.Store.Present() {
+		t.Error("expected Optional field to be present when registered")
+	}
+	if deps.unused != nil {
+		t.Error("expected unexported field to be left alone")
+	}
+}
+
+func TestIn_OptionalFieldsNotRegistered_LeavesThemEmpty(t *testing.T) {
+	c := gotainer.NewContainer()
+	registerReportDeps(c)
+	gotainer.MustRegisterTransient[DetailedReportService](c, NewDetailedReportService)
+
+	service := gotainer.MustResolve[DetailedReportService](c)
+
+	if service.deps.Metrics != nil {
+		t.Error("expected optional field to be nil when not registered")
+	}
+	if service.deps.Store.Present() {
+		t.Error("expected Optional field to be absent when not registered")
+	}
+}
+
+func TestIn_MissingNamedField_ReturnsPrefetchArgumentError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonNamed[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+
+	err := gotainer.RegisterTransient[DetailedReportService](c, NewDetailedReportService)
+
+	prefetchErr := &gotainer.PrefetchArgumentError{}
+	if !errors.As(err, &prefetchErr) {
+		t.Errorf("expected error to be PrefetchArgumentError, got %v", err)
+		return
+	}
+	if prefetchErr.DependencyName != "Database[replica]" {
+		t.Errorf("expected missing dependency Database[replica], got %q", prefetchErr.DependencyName)
+	}
+}
+
+func TestIn_Invoke_InjectsEachField(t *testing.T) {
+	c := gotainer.NewContainer()
+	registerReportDeps(c)
+
+	var primary *Database
+	err := gotainer.Invoke(c, func(deps ReportDeps) {
+		primary = deps.Primary
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if primary != gotainer.MustResolveNamed[Database](c, "primary") {
+		t.Error("expected invoked fn to receive the named field")
+	}
+}
+
+func TestIn_InvalidTag_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterTransient[DetailedReportService](c, BadCtorForDetailedReportServiceTag)
+
+	if !errors.As(err, new(*gotainer.ConstructorMismatchError)) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestIn_GroupTagOnSingleValue_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterTransient[DetailedReportService](c, BadCtorForDetailedReportServiceGroup)
+
+	if !errors.As(err, new(*gotainer.ConstructorMismatchError)) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package gotainer
+
+import "reflect"
+
+// RegisterInstance registers an already constructed instance as a singleton of T. The instance is
+// closed with the Container like any other singleton unless registered WithoutCleanup.
+func RegisterInstance[T any](container *Container, instance *T, opts ...RegisterOption) error {
+	return RegisterInstanceNamed[T](container, "", instance, opts...)
+}
+
+// RegisterInstanceNamed registers an already constructed instance as a singleton of T under name.
+func RegisterInstanceNamed[T any](container *Container, name string, instance *T, opts ...RegisterOption) error {
+	return registerInstance(container, keyOf[T](name), reflect.ValueOf(instance), opts)
+}
+
+// RegisterInstanceInterface registers an already constructed instance as a singleton of the
+// interface T, typically a test double standing in for the real implementation.
+func RegisterInstanceInterface[T any](container *Container, instance T, opts ...RegisterOption) error {
+	return RegisterInstanceInterfaceNamed[T](container, "", instance, opts...)
+}
+
+// RegisterInstanceInterfaceNamed registers an already constructed instance as a singleton of the
+// interface T under name.
+func RegisterInstanceInterfaceNamed[T any](container *Container, name string, instance T, opts ...RegisterOption) error {
+	k := keyOf[T](name)
+	if k.t.Kind() != reflect.Interface {
+		return NewConstructorMismatchError("instance registered as an interface must be of an interface type, use RegisterInstance for other types")
+	}
+	return registerInstance(container, k, reflect.ValueOf(&instance).Elem(), opts)
+}
+
+func MustRegisterInstance[T any](container *Container, instance *T, opts ...RegisterOption) {
+	err := RegisterInstance[T](container, instance, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterInstanceNamed[T any](container *Container, name string, instance *T, opts ...RegisterOption) {
+	err := RegisterInstanceNamed[T](container, name, instance, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterInstanceInterface[T any](container *Container, instance T, opts ...RegisterOption) {
+	err := RegisterInstanceInterface[T](container, instance, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterInstanceInterfaceNamed[T any](container *Container, name string, instance T, opts ...RegisterOption) {
+	err := RegisterInstanceInterfaceNamed[T](container, name, instance, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// registerInstance registers instance as a singleton under k and stores it straight away, so that
+// it is owned by the container even if never resolved. A Container closed meanwhile rejects the
+// registration with a ContainerClosedError and leaves the instance untouched.
+func registerInstance(container *Container, k key, instance reflect.Value, opts []RegisterOption) error {
+	ctorType := reflect.FuncOf(nil, []reflect.Type{instance.Type(), errorType}, false)
+	ctor := reflect.MakeFunc(ctorType, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{instance, reflect.Zero(errorType)}
+	})
+	dispose := newDisposer(ctorType, newRegistrationOptions(opts))
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	return container.disposables.addWith(func() (func() error, error) {
+		err := registerLocked(container, k, ctor.Interface(), LifetimeSingleton, opts)
+		if err != nil {
+			return nil, err
+		}
+		container.singletons[k] = instance
+		return dispose(instance), nil
+	})
+}
@@ -0,0 +1,159 @@
+package gotainer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestInstance_RegisterInstance_ResolvesSameInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	instance := &SimpleStruct{data: 42}
+	err := gotainer.RegisterInstance[SimpleStruct](c, instance)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	resolved, err := gotainer.Resolve[SimpleStruct](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resolved != instance {
+		t.Error("expected the registered instance to be resolved")
+	}
+}
+
+func TestInstance_RegisterInterfaceInstance_InjectsInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	repo := &MemoryRepository{records: map[int]string{1: "instance"}}
+	gotainer.MustRegisterInstanceInterface[Repository](c, repo)
+	gotainer.MustRegisterInstanceInterface[Logger](c, &RecordingLogger{})
+	gotainer.MustRegisterTransient[Handler](c, NewHandler)
+
+	handler := gotainer.MustResolve[Handler](c)
+
+	if handler.repo != Repository(repo) {
+		t.Error("expected the registered interface instance to be injected")
+	}
+	if handler.Handle(1) != "instance" {
+		t.Error("expected the injected instance to be usable")
+	}
+}
+
+func TestInstance_RegisterInstanceDeferred_ParticipatesInValidation(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterTransient[TierOneType](c, NewTierOneType)
+	gotainer.MustRegisterInstance[TierTwoTypeOne](c, &TierTwoTypeOne{data: 7})
+
+	err := c.Validate()
+	missingErr := &gotainer.MissingDependencyError{}
+	if !errors.As(err, &missingErr) {
+		t.Errorf("expected error to be MissingDependencyError, got %v", err)
+		return
+	}
+
+	gotainer.MustRegisterInstance[TierTwoTypeTwo](c, &TierTwoTypeTwo{data: "instance"})
+	err = c.Validate()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	resolved := gotainer.MustResolve[TierOneType](c)
+	if resolved.ref.data != 7 || resolved.ref2.data != "instance" {
+		t.Error("expected registered instances to be injected")
+	}
+}
+
+func TestInstance_CloseWithUnresolvedClosableInstance_ClosesInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	log := &CloseLog{}
+	gotainer.MustRegisterInstance[ClosableLeaf](c, &ClosableLeaf{log: log})
+
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(log.closed) != 1 {
+		t.Errorf("expected the registered instance to be closed, got %v", log.closed)
+	}
+}
+
+func TestInstance_CloseWithoutCleanup_DoesNotCloseInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	log := &CloseLog{}
+	gotainer.MustRegisterInstance[ClosableLeaf](c, &ClosableLeaf{log: log}, gotainer.WithoutCleanup())
+	gotainer.MustResolve[ClosableLeaf](c)
+
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(log.closed) != 0 {
+		t.Errorf("expected the unowned instance not to be closed, got %v", log.closed)
+	}
+}
+
+func TestInstance_RegisterInstanceInterfaceOfStructType_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterInstanceInterface[SimpleStruct](c, SimpleStruct{data: 1})
+
+	ctorErr := &gotainer.ConstructorMismatchError{}
+	if !errors.As(err, &ctorErr) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestInstance_RegisterInstanceTwice_ReturnsDuplicateRegistrationError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterInstance[SimpleStruct](c, &SimpleStruct{data: 1})
+
+	err := gotainer.RegisterInstance[SimpleStruct](c, &SimpleStruct{data: 2})
+
+	dupErr := &gotainer.DuplicateRegistrationError{}
+	if !errors.As(err, &dupErr) {
+		t.Errorf("expected error to be DuplicateRegistrationError, got %v", err)
+	}
+}
+
+func TestInstance_RegisterInstanceInterfaceWithImplementation_ResolvesInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	store := &PostgresStore{dsn: "postgres://test-double"}
+
+	err := gotainer.RegisterInstanceInterface[Store](c, store)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if gotainer.MustResolveInterface[Store](c) != Store(store) {
+		t.Error("expected the registered implementation to be resolved for the interface")
+	}
+}
+
+func TestInstance_RegisterInstanceOnClosedContainer_ReturnsErrorAndDoesNotCloseInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	log := &CloseLog{}
+	_ = c.Close(context.Background())
+
+	err := gotainer.RegisterInstance[ClosableLeaf](c, &ClosableLeaf{log: log})
+
+	closedErr := &gotainer.ContainerClosedError{}
+	if !errors.As(err, &closedErr) {
+		t.Errorf("expected error to be ContainerClosedError, got %v", err)
+		return
+	}
+	if len(log.closed) != 0 {
+		t.Errorf("expected the rejected instance not to be closed, got %v", log.closed)
+	}
+}
@@ -0,0 +1,152 @@
+package gotainer_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestInterface_CtorWithInterfaceParams_InjectsDynamicTypes(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Repository](c, NewMemoryRepository)
+	gotainer.MustRegisterSingleton[Logger](c, NewRecordingLogger)
+	gotainer.MustRegisterTransient[Handler](c, NewHandler)
+
+	handler, err := gotainer.Resolve[Handler](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, ok := handler.repo.(*MemoryRepository); !ok {
+		t.Errorf("expected repository to be *MemoryRepository, got %T", handler.repo)
+	}
+	logger, ok := handler.log.(*RecordingLogger)
+	if !ok {
+		t.Errorf("expected logger to be *RecordingLogger, got %T", handler.log)
+		return
+	}
+
+	if record := handler.Handle(1); record != "one" {
+		t.Errorf("expected injected repository methods to work, got %q", record)
+	}
+	if !slices.Equal(logger.messages, []string{"found one"}) {
+		t.Errorf("expected injected logger methods to work, got %v", logger.messages)
+	}
+}
+
+func TestInterface_SingletonInterfaceParams_InjectsSameInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Repository](c, NewMemoryRepository)
+	gotainer.MustRegisterSingleton[Logger](c, NewRecordingLogger)
+	gotainer.MustRegisterTransient[Handler](c, NewHandler)
+
+	first := gotainer.MustResolve[Handler](c)
+	second := gotainer.MustResolve[Handler](c)
+
+	if first.repo != second.repo || first.log != second.log {
+		t.Error("expected singleton interfaces to be injected as the same instance")
+	}
+	if first.repo != gotainer.MustResolveInterface[Repository](c) {
+		t.Error("expected injected interface to be the resolved singleton")
+	}
+}
+
+func TestInterface_TransientInterfaceParams_InjectsNewInstances(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[Repository](c, NewMemoryRepository)
+	gotainer.MustRegisterTransient[Logger](c, NewRecordingLogger)
+	gotainer.MustRegisterTransient[Handler](c, NewHandler)
+
+	first := gotainer.MustResolve[Handler](c)
+	second := gotainer.MustResolve[Handler](c)
+
+	if first.repo == second.repo || first.log == second.log {
+		t.Error("expected transient interfaces to be injected as new instances")
+	}
+}
+
+func TestInterface_ScopedInterfaceParams_InjectsInstancePerScope(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Repository](c, NewMemoryRepository)
+	gotainer.MustRegisterScoped[Logger](c, NewRecordingLogger)
+	gotainer.MustRegisterTransient[Handler](c, NewHandler)
+
+	scope := c.NewScope()
+	first := gotainer.MustResolve[Handler](scope)
+	second := gotainer.MustResolve[Handler](scope)
+	other := gotainer.MustResolve[Handler](c.NewScope())
+
+	if first.log != second.log {
+		t.Error("expected scoped interface to be shared within a scope")
+	}
+	if first.log == other.log {
+		t.Error("expected scoped interface to differ across scopes")
+	}
+}
+
+func TestInterface_RegisterWithMissingInterfaceParam_ReturnsPrefetchError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Repository](c, NewMemoryRepository)
+
+	err := gotainer.RegisterTransient[Handler](c, NewHandler)
+
+	prefetchErr := &gotainer.PrefetchArgumentError{}
+	if !errors.As(err, &prefetchErr) {
+		t.Errorf("expected error to be PrefetchArgumentError, got %v", err)
+		return
+	}
+
+	if prefetchErr.DependencyName != "Logger" {
+		t.Errorf("expected error to be for Logger was for %s", prefetchErr.DependencyName)
+	}
+}
+
+func TestInterface_RegisterWithValueParam_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+
+	err := gotainer.RegisterTransient[Handler](c, BadCtorForHandlerValueParam)
+
+	ctorErr := &gotainer.ConstructorMismatchError{}
+	if !errors.As(err, &ctorErr) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestInterface_RegisterWithPointerToInterfaceParam_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Repository](c, NewMemoryRepository)
+
+	err := gotainer.RegisterTransient[Handler](c, BadCtorForHandlerPointerToInterfaceParam)
+
+	ctorErr := &gotainer.ConstructorMismatchError{}
+	if !errors.As(err, &ctorErr) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestInterface_InvokeWithInterfaceParams_InjectsDynamicTypes(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Repository](c, NewMemoryRepository)
+	gotainer.MustRegisterSingleton[Logger](c, NewRecordingLogger)
+
+	err := gotainer.Invoke(c, func(repo Repository, log Logger) error {
+		if repo.Find(1) != "one" {
+			return errors.New("unexpected repository")
+		}
+		log.Log("invoked")
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	logger := gotainer.MustResolveInterface[Logger](c).(*RecordingLogger)
+	if !slices.Equal(logger.messages, []string{"invoked"}) {
+		t.Errorf("expected invoked fn to use the singleton logger, got %v", logger.messages)
+	}
+}
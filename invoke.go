@@ -0,0 +1,65 @@
+package gotainer
+
+import (
+	"context"
+	"reflect"
+)
+
+// Invoke calls fn with each of its parameters resolved from the resolver, following the same rules
+// as ctor parameters. fn must return either nothing or a single error, which is returned by Invoke.
+func Invoke(resolver Resolver, fn any) error {
+	return InvokeContext(context.Background(), resolver, fn)
+}
+
+// InvokeContext is Invoke resolving with ctx, which is passed to fn and to ctors accepting a context.
+func InvokeContext(ctx context.Context, resolver Resolver, fn any) error {
+	fnType := reflect.TypeOf(fn)
+	err := testInvokeFn(resolver.root(), fnType)
+	if err != nil {
+		return err
+	}
+
+	inj := newInjector(ctx, resolver)
+	vals, err := resolveArgs(inj, paramsOf(fnType, nil))
+	if err != nil {
+		return err
+	}
+
+	results := reflect.ValueOf(fn).Call(vals)
+	if len(results) == 1 && !results[0].IsNil() {
+		return results[0].Interface().(error)
+	}
+	return nil
+}
+
+func MustInvoke(resolver Resolver, fn any) {
+	err := Invoke(resolver, fn)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustInvokeContext(ctx context.Context, resolver Resolver, fn any) {
+	err := InvokeContext(ctx, resolver, fn)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func testInvokeFn(container *Container, fnType reflect.Type) error {
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return NewInvokeMismatchError("fn must be a function")
+	}
+
+	if fnType.NumOut() > 1 || (fnType.NumOut() == 1 && fnType.Out(0) != errorType) {
+		return NewInvokeMismatchError("fn must return nothing or a single error")
+	}
+
+	if reason, ok := findInvalidParam(fnType); ok {
+		return NewInvokeMismatchError("fn " + reason)
+	}
+
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	return findPrefetchErrors(container, dependenciesOfParams(paramsOf(fnType, nil)), typeName(fnType))
+}
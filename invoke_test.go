@@ -0,0 +1,137 @@
+package gotainer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestInvoke_FnWithRegisteredParams_CallsFnWithResolvedParams(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[SimpleStruct](c, NewSimpleStruct)
+	gotainer.MustRegisterTransient[InterfaceType](c, NewInterfaceableType)
+	expected := gotainer.MustResolve[SimpleStruct](c)
+
+	called := false
+	err := gotainer.Invoke(c, func(s *SimpleStruct, i InterfaceType) {
+		called = true
+		if s != expected {
+			t.Error("expected invoked fn to receive the registered singleton")
+		}
+		if _, ok := i.(*InterfaceableType); !ok {
+			t.Errorf("expected invoked fn to receive the registered interface, got %T", i)
+		}
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !called {
+		t.Error("expected fn to be invoked")
+	}
+}
+
+func TestInvoke_FnReturningError_ReturnsError(t *testing.T) {
+	c := gotainer.NewContainer()
+	fnErr := errors.New("fn error")
+
+	err := gotainer.Invoke(c, func() error {
+		return fnErr
+	})
+
+	if !errors.Is(err, fnErr) {
+		t.Errorf("expected error to be fn error, got %v", err)
+	}
+}
+
+func TestInvoke_FnReturningNilError_ReturnsNil(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[SimpleStruct](c, NewSimpleStruct)
+
+	err := gotainer.Invoke(c, func(s *SimpleStruct) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvoke_FnWithScopedParam_ResolvesFromScope(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterScoped[SimpleStruct](c, NewSimpleStruct)
+	scope := c.NewScope()
+	expected := gotainer.MustResolve[SimpleStruct](scope)
+
+	err := gotainer.Invoke(scope, func(s *SimpleStruct) {
+		if s != expected {
+			t.Error("expected invoked fn to receive the scoped instance")
+		}
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvoke_FnWithUnregisteredParam_ReturnsPrefetchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.Invoke(c, func(s *SimpleStruct) {
+		t.Error("expected fn not to be invoked")
+	})
+
+	prefetchErr := &gotainer.PrefetchArgumentError{}
+	if !errors.As(err, &prefetchErr) {
+		t.Errorf("expected error to be PrefetchArgumentError, got %v", err)
+		return
+	}
+
+	if prefetchErr.DependencyName != "SimpleStruct" {
+		t.Errorf("expected error to be for SimpleStruct was for %s", prefetchErr.DependencyName)
+	}
+}
+
+func TestInvoke_WithCtorError_ReturnsErrorWithoutCalling(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[TierTwoTypeTwo](c, NewErroringTierTwoTypeTwo)
+
+	err := gotainer.Invoke(c, func(s *TierTwoTypeTwo) {
+		t.Error("expected fn not to be invoked")
+	})
+
+	if !errors.Is(err, TierTwoTypeTwoError) {
+		t.Errorf("expected error to be TierTwoTypeTwoError, got %v", err)
+	}
+}
+
+func TestInvoke_BadFn_ReturnsInvokeMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+	badFns := []any{
+		3,
+		nil,
+		func() int { return 3 },
+		func() (int, error) { return 3, nil },
+	}
+
+	for _, fn := range badFns {
+		err := gotainer.Invoke(c, fn)
+		invokeErr := &gotainer.InvokeMismatchError{}
+		if !errors.As(err, &invokeErr) {
+			t.Errorf("expected error to be InvokeMismatchError for %T, got %v", fn, err)
+		}
+	}
+}
+
+func TestInvoke_MustInvokeWithError_Panics(t *testing.T) {
+	c := gotainer.NewContainer()
+	defer func() { _ = recover() }()
+
+	gotainer.MustInvoke(c, func() error {
+		return errors.New("fn error")
+	})
+
+	t.Error("expected panic")
+}
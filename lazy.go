@@ -0,0 +1,108 @@
+package gotainer
+
+import (
+	"reflect"
+	"sync"
+)
+
+var deferredType = reflect.TypeOf((*deferred)(nil)).Elem()
+
+// Lazy is injected in place of T to delay constructing T until Get is first called, after which
+// the same instance is always returned. Lazy dependencies may form cycles as long as Get is not
+// called while the cycle is still being constructed.
+type Lazy[T any] struct {
+	get func() (T, error)
+}
+
+// Get constructs the dependency on first use and returns the cached instance afterwards, a failed
+// construction is retried by the next call.
+func (l Lazy[T]) Get() (T, error) {
+	return l.get()
+}
+
+func (l Lazy[T]) MustGet() T {
+	res, err := l.Get()
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func (l Lazy[T]) injectedType() reflect.Type {
+	return typeOf[T]()
+}
+
+func (l *Lazy[T]) bind(resolve func() (reflect.Value, error)) {
+	var mu sync.Mutex
+	var value T
+	var done bool
+	l.get = func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return value, nil
+		}
+		val, err := resolve()
+		if err != nil {
+			return value, err
+		}
+		value, done = valueAs[T](val), true
+		return value, nil
+	}
+}
+
+// Provider is injected in place of T to resolve T on demand, every call to Get resolves T again
+// following its lifetime. Like Lazy, Provider dependencies may form cycles.
+type Provider[T any] struct {
+	get func() (T, error)
+}
+
+// Get resolves the dependency, a new instance for transients and the shared one otherwise.
+func (p Provider[T]) Get() (T, error) {
+	return p.get()
+}
+
+func (p Provider[T]) MustGet() T {
+	res, err := p.Get()
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func (p Provider[T]) injectedType() reflect.Type {
+	return typeOf[T]()
+}
+
+func (p *Provider[T]) bind(resolve func() (reflect.Value, error)) {
+	p.get = func() (T, error) {
+		val, err := resolve()
+		if err != nil {
+			var defaultVal T
+			return defaultVal, err
+		}
+		return valueAs[T](val), nil
+	}
+}
+
+// deferred is implemented by Lazy and Provider, letting parameters be recognised without knowing T.
+type deferred interface {
+	injectedType() reflect.Type
+	bind(resolve func() (reflect.Value, error))
+}
+
+func isDeferred(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(deferredType)
+}
+
+// deferredOf returns the T of the Lazy[T] or Provider[T] type t.
+func deferredOf(t reflect.Type) reflect.Type {
+	return reflect.New(t).Interface().(deferred).injectedType()
+}
+
+// newDeferred returns a Lazy or Provider of type t which resolves its dependency with resolve.
+func newDeferred(t reflect.Type, resolve func() (reflect.Value, error)) reflect.Value {
+	d := reflect.New(t)
+	d.Interface().(deferred).bind(resolve)
+	return d.Elem()
+}
@@ -0,0 +1,152 @@
+package gotainer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestLazy_Get_ConstructsOnFirstCallAndCaches(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterTransient[ExpensiveService](c, NewExpensiveServiceFactory(&constructed))
+	gotainer.MustRegisterTransient[LazyConsumer](c, NewLazyConsumer)
+
+	consumer := gotainer.MustResolve[LazyConsumer](c)
+	if constructed != 0 {
+		t.Errorf("expected no construction before Get, got %d", constructed)
+		return
+	}
+
+	first, err := consumer.service.Get()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	second := consumer.service.MustGet()
+
+	if constructed != 1 {
+		t.Errorf("expected a single construction, got %d", constructed)
+	}
+	if first != second {
+		t.Error("expected Lazy to cache the constructed instance")
+	}
+}
+
+func TestProvider_Get_ResolvesTransientPerCall(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterTransient[ExpensiveService](c, NewExpensiveServiceFactory(&constructed))
+	gotainer.MustRegisterTransient[ProviderConsumer](c, NewProviderConsumer)
+
+	consumer := gotainer.MustResolve[ProviderConsumer](c)
+	first, err := consumer.service.Get()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	second := consumer.service.MustGet()
+
+	if constructed != 2 || first == second {
+		t.Errorf("expected a new transient per Get, got %d constructions", constructed)
+	}
+}
+
+func TestProvider_Get_ReturnsSharedSingleton(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterSingleton[ExpensiveService](c, NewExpensiveServiceFactory(&constructed))
+	gotainer.MustRegisterTransient[ProviderConsumer](c, NewProviderConsumer)
+
+	consumer := gotainer.MustResolve[ProviderConsumer](c)
+
+	if consumer.service.MustGet() != gotainer.MustResolve[ExpensiveService](c) || constructed != 1 {
+		t.Error("expected Provider to follow the singleton lifetime")
+	}
+}
+
+func TestProvider_Get_FollowsScopeOfResolve(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterScoped[ExpensiveService](c, NewExpensiveServiceFactory(&constructed))
+	gotainer.MustRegisterScoped[ProviderConsumer](c, NewProviderConsumer)
+	scope := c.NewScope()
+
+	consumer := gotainer.MustResolve[ProviderConsumer](scope)
+
+	if consumer.service.MustGet() != gotainer.MustResolve[ExpensiveService](scope) {
+		t.Error("expected Provider to resolve from the scope it was injected from")
+	}
+}
+
+func TestLazy_Cycle_IsAllowedAndResolves(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[LazyCycleA](c, NewLazyCycleA)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = gotainer.RegisterSingleton[LazyCycleB](c, NewLazyCycleB)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a := gotainer.MustResolve[LazyCycleA](c)
+	b, err := a.b.Get()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if b.a != a {
+		t.Error("expected the cycle to be closed through the Lazy")
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected a cycle through Lazy to be valid, got %v", err)
+	}
+}
+
+func TestLazy_GetDuringCycleConstruction_ReturnsCircularDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[LazyCycleA](c, NewEagerLazyCycleA)
+	gotainer.MustRegisterSingleton[LazyCycleB](c, NewLazyCycleB)
+
+	_, err := gotainer.Resolve[LazyCycleA](c)
+
+	if !errors.As(err, new(*gotainer.CircularDependencyError)) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+	}
+}
+
+func TestLazy_NeverRegistered_ReportedByValidate(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[LazyCycleA](c, NewLazyCycleA)
+
+	err := c.Validate()
+
+	if !errors.As(err, new(*gotainer.MissingDependencyError)) {
+		t.Errorf("expected error to contain MissingDependencyError, got %v", err)
+	}
+}
+
+func TestLazy_GetAfterClose_ReturnsContainerClosedError(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterTransient[ExpensiveService](c, NewExpensiveServiceFactory(&constructed))
+	gotainer.MustRegisterTransient[LazyConsumer](c, NewLazyConsumer)
+	consumer := gotainer.MustResolve[LazyConsumer](c)
+
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = consumer.service.Get()
+
+	if !errors.As(err, new(*gotainer.ContainerClosedError)) {
+		t.Errorf("expected error to be ContainerClosedError, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package gotainer
+
+import (
+	"context"
+	"reflect"
+)
+
+// RegisterTransientMapEntry adds a transient T to the map of T under mapKey, ctors taking a
+// map[string]T, or map[string]*T for struct types, receive every entry of the map.
+func RegisterTransientMapEntry[T any, Fn any](container *Container, mapKey string, ctor Fn, opts ...RegisterOption) error {
+	return registerGroupMember(container, typeOf[T](), mapMembership[T](mapKey), ctor, LifetimeTransient, opts)
+}
+
+// RegisterScopedMapEntry adds a scoped T to the map of T under mapKey.
+func RegisterScopedMapEntry[T any, Fn any](container *Container, mapKey string, ctor Fn, opts ...RegisterOption) error {
+	return registerGroupMember(container, typeOf[T](), mapMembership[T](mapKey), ctor, LifetimeScoped, opts)
+}
+
+// RegisterSingletonMapEntry adds a singleton T to the map of T under mapKey.
+func RegisterSingletonMapEntry[T any, Fn any](container *Container, mapKey string, ctor Fn, opts ...RegisterOption) error {
+	return registerGroupMember(container, typeOf[T](), mapMembership[T](mapKey), ctor, LifetimeSingleton, opts)
+}
+
+func MustRegisterTransientMapEntry[T any, Fn any](container *Container, mapKey string, ctor Fn, opts ...RegisterOption) {
+	err := RegisterTransientMapEntry[T, Fn](container, mapKey, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterScopedMapEntry[T any, Fn any](container *Container, mapKey string, ctor Fn, opts ...RegisterOption) {
+	err := RegisterScopedMapEntry[T, Fn](container, mapKey, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterSingletonMapEntry[T any, Fn any](container *Container, mapKey string, ctor Fn, opts ...RegisterOption) {
+	err := RegisterSingletonMapEntry[T, Fn](container, mapKey, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// InMap adds the type being registered to the map of T under mapKey as well as registering it as
+// usual. T must be the registered type or an interface it implements.
+func InMap[T any](mapKey string) RegisterOption {
+	return inGroup(mapMembership[T](mapKey))
+}
+
+// ResolveMap resolves every entry of the map of T, a map without entries resolves to an empty map.
+func ResolveMap[T any](resolver Resolver) (map[string]*T, error) {
+	group := mapGroupKey(typeOf[T]())
+	val, err := resolver.resolve(context.Background(), group)
+	if err != nil {
+		return nil, err
+	}
+	if group.t.Elem().Kind() == reflect.Interface {
+		// interface entries are held as the interface itself, hand back pointers to copies of them
+		res := make(map[string]*T, val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			entry := valueAs[T](iter.Value())
+			res[iter.Key().String()] = &entry
+		}
+		return res, nil
+	}
+	return valueAs[map[string]*T](val), nil
+}
+
+func MustResolveMap[T any](resolver Resolver) map[string]*T {
+	res, err := ResolveMap[T](resolver)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ResolveMapInterface resolves every entry of the map of the interface T.
+func ResolveMapInterface[T any](resolver Resolver) (map[string]T, error) {
+	val, err := resolver.resolve(context.Background(), mapGroupKey(typeOf[T]()))
+	if err != nil {
+		return nil, err
+	}
+	return valueAs[map[string]T](val), nil
+}
+
+func MustResolveMapInterface[T any](resolver Resolver) map[string]T {
+	res, err := ResolveMapInterface[T](resolver)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// mapGroupKey returns the key of the map of elemType, which resolves to a string keyed map of the
+// injected type.
+func mapGroupKey(elemType reflect.Type) key {
+	return key{t: reflect.MapOf(stringType, injectedType(elemType)), group: true}
+}
+
+func mapMembership[T any](mapKey string) membership {
+	return membership{group: mapGroupKey(typeOf[T]()), mapKey: mapKey}
+}
@@ -0,0 +1,150 @@
+package gotainer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestRegisterMapEntry_MapParam_ReceivesEveryEntry(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[ExporterRegistry](c, NewExporterRegistry)
+	gotainer.MustRegisterSingletonMapEntry[Exporter](c, "otlp", NewExporterFactory("otlp-exporter"))
+	err := gotainer.RegisterTransientMapEntry[Exporter](c, "stdout", NewExporterFactory("stdout-exporter"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	registry := gotainer.MustResolve[ExporterRegistry](c)
+
+	if len(registry.exporters) != 2 {
+		t.Errorf("expected 2 exporters, got %d", len(registry.exporters))
+		return
+	}
+	if registry.exporters["otlp"].Export() != "otlp-exporter" || registry.exporters["stdout"].Export() != "stdout-exporter" {
+		t.Error("expected each exporter under its own key")
+	}
+}
+
+func TestRegisterMapEntry_DuplicateKey_ReturnsDuplicateMapKeyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonMapEntry[Exporter](c, "otlp", NewExporterFactory("otlp-exporter"))
+
+	err := gotainer.RegisterSingletonMapEntry[Exporter](c, "otlp", NewExporterFactory("other-exporter"))
+
+	duplicateErr := &gotainer.DuplicateMapKeyError{}
+	if !errors.As(err, &duplicateErr) {
+		t.Errorf("expected error to be DuplicateMapKeyError, got %v", err)
+		return
+	}
+	if duplicateErr.Key != "otlp" {
+		t.Errorf("expected duplicate key otlp, got %q", duplicateErr.Key)
+	}
+	if gotainer.MustResolveMapInterface[Exporter](c)["otlp"].Export() != "otlp-exporter" {
+		t.Error("expected the original entry to be kept")
+	}
+}
+
+func TestInMap_DuplicateKey_ReturnsDuplicateMapKeyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonMapEntry[Exporter](c, "otlp", NewExporterFactory("otlp-exporter"))
+
+	err := gotainer.RegisterSingleton[Exporter](c, NewExporterFactory("default-exporter"), gotainer.InMap[Exporter]("otlp"))
+
+	if !errors.As(err, new(*gotainer.DuplicateMapKeyError)) {
+		t.Errorf("expected error to be DuplicateMapKeyError, got %v", err)
+	}
+}
+
+func TestInMap_SameKeyTwiceInOneRegistration_ReturnsDuplicateMapKeyError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterSingleton[PostgresStore](c, NewPostgresStore, gotainer.InMap[Store]("postgres"), gotainer.InMap[Store]("postgres"))
+
+	duplicateErr := &gotainer.DuplicateMapKeyError{}
+	if !errors.As(err, &duplicateErr) {
+		t.Errorf("expected error to be DuplicateMapKeyError, got %v", err)
+		return
+	}
+	if duplicateErr.Key != "postgres" {
+		t.Errorf("expected duplicate key postgres, got %q", duplicateErr.Key)
+	}
+}
+
+func TestRegisterMapEntry_InMapWithSameKey_ReturnsDuplicateMapKeyError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterSingletonMapEntry[Exporter](c, "otlp", NewExporterFactory("otlp-exporter"), gotainer.InMap[Exporter]("otlp"))
+
+	if !errors.As(err, new(*gotainer.DuplicateMapKeyError)) {
+		t.Errorf("expected error to be DuplicateMapKeyError, got %v", err)
+		return
+	}
+	if entries := gotainer.MustResolveMapInterface[Exporter](c); len(entries) != 0 {
+		t.Errorf("expected failed registration to add no entries, got %v", entries)
+	}
+}
+
+func TestInMap_Registration_JoinsMapAndResolvesSameInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterSingleton[PostgresStore](c, NewPostgresStore, gotainer.InMap[Store]("postgres"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	stores, err := gotainer.ResolveMapInterface[Store](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if stores["postgres"] != Store(gotainer.MustResolve[PostgresStore](c)) {
+		t.Error("expected map entry to be the registered singleton")
+	}
+}
+
+func TestResolveMap_StructEntries_ReturnsPointers(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonMapEntry[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+	gotainer.MustRegisterSingletonGroup[Database](c, NewDatabaseFactory("group-dsn"))
+
+	dbs, err := gotainer.ResolveMap[Database](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(dbs) != 1 || dbs["primary"].dsn != "primary-dsn" {
+		t.Errorf("expected only the map entry, got %v", dbs)
+	}
+	if len(gotainer.MustResolveAll[Database](c)) != 1 {
+		t.Error("expected slice and map groups to be kept apart")
+	}
+}
+
+func TestResolveMap_NoEntries_ReturnsEmptyMap(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	exporters, err := gotainer.ResolveMapInterface[Exporter](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if exporters == nil || len(exporters) != 0 {
+		t.Errorf("expected an empty map, got %v", exporters)
+	}
+}
+
+func TestRegister_NonStringMapKeyParam_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterTransient[ExporterRegistry](c, BadCtorForExporterRegistryIntKeys)
+
+	if !errors.As(err, new(*gotainer.ConstructorMismatchError)) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
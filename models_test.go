@@ -1,6 +1,13 @@
 package gotainer_test
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BlindGarret/gotainer"
+)
 
 type SimpleStruct struct {
 	data int
@@ -74,3 +81,631 @@ type InterfaceType interface {
 func NewInterfaceableType() (InterfaceType, error) {
 	return &InterfaceableType{}, nil
 }
+
+func NewIntSlice() (*[]int, error) {
+	s := []int{1, 2, 3}
+	return &s, nil
+}
+
+func NewStringMap() (*map[string]string, error) {
+	m := map[string]string{"key": "value"}
+	return &m, nil
+}
+
+// SimpleStructAlias lets tests refer to the package level SimpleStruct where it is shadowed.
+type SimpleStructAlias = SimpleStruct
+
+// CloseLog records the order in which closable test types were torn down.
+type CloseLog struct {
+	closed []string
+}
+
+func NewCloseLog() (*CloseLog, error) {
+	return &CloseLog{}, nil
+}
+
+type ClosableLeaf struct {
+	log *CloseLog
+	err error
+}
+
+func NewClosableLeaf(log *CloseLog) (*ClosableLeaf, error) {
+	return &ClosableLeaf{log: log}, nil
+}
+
+func (c *ClosableLeaf) Close() error {
+	c.log.closed = append(c.log.closed, "ClosableLeaf")
+	return c.err
+}
+
+type ClosableRoot struct {
+	leaf *ClosableLeaf
+	err  error
+}
+
+func NewClosableRoot(leaf *ClosableLeaf) (*ClosableRoot, error) {
+	return &ClosableRoot{leaf: leaf}, nil
+}
+
+func (c *ClosableRoot) Close() error {
+	c.leaf.log.closed = append(c.leaf.log.closed, "ClosableRoot")
+	return c.err
+}
+
+func NewTierTwoTypeOneWithCleanup(log *CloseLog) func() (*TierTwoTypeOne, func(), error) {
+	return func() (*TierTwoTypeOne, func(), error) {
+		return &TierTwoTypeOne{data: 1}, func() { log.closed = append(log.closed, "TierTwoTypeOne") }, nil
+	}
+}
+
+func NewTierOneTypeWithCleanup(log *CloseLog) func(*TierTwoTypeOne, *TierTwoTypeTwo) (*TierOneType, func() error, error) {
+	return func(ref *TierTwoTypeOne, ref2 *TierTwoTypeTwo) (*TierOneType, func() error, error) {
+		cleanup := func() error {
+			log.closed = append(log.closed, "TierOneType")
+			return nil
+		}
+		return &TierOneType{ref: ref, ref2: ref2}, cleanup, nil
+	}
+}
+
+func BadCtorForSimpleStructNonFuncCleanup() (*SimpleStruct, int, error) {
+	return &SimpleStruct{data: 4}, 4, nil
+}
+
+type SelfReferencingType struct {
+	ref *SelfReferencingType
+}
+
+func NewSelfReferencingType(ref *SelfReferencingType) (*SelfReferencingType, error) {
+	return &SelfReferencingType{ref: ref}, nil
+}
+
+type CycleTypeA struct {
+	ref *CycleTypeB
+}
+
+func NewCycleTypeA(ref *CycleTypeB) (*CycleTypeA, error) {
+	return &CycleTypeA{ref: ref}, nil
+}
+
+type CycleTypeB struct {
+	ref *CycleTypeC
+}
+
+func NewCycleTypeB(ref *CycleTypeC) (*CycleTypeB, error) {
+	return &CycleTypeB{ref: ref}, nil
+}
+
+type CycleTypeC struct {
+	ref *CycleTypeA
+}
+
+func NewCycleTypeC(ref *CycleTypeA) (*CycleTypeC, error) {
+	return &CycleTypeC{ref: ref}, nil
+}
+
+type InterfaceConsumer struct {
+	dep    InterfaceType
+	simple *SimpleStruct
+}
+
+func NewInterfaceConsumer(dep InterfaceType, simple *SimpleStruct) (*InterfaceConsumer, error) {
+	return &InterfaceConsumer{dep: dep, simple: simple}, nil
+}
+
+func NewNilInterfaceType() (InterfaceType, error) {
+	return nil, nil
+}
+
+type Repository interface {
+	Find(id int) string
+}
+
+type MemoryRepository struct {
+	records map[int]string
+}
+
+func (r *MemoryRepository) Find(id int) string {
+	return r.records[id]
+}
+
+func NewMemoryRepository() (Repository, error) {
+	return &MemoryRepository{records: map[int]string{1: "one"}}, nil
+}
+
+type Logger interface {
+	Log(msg string)
+}
+
+type RecordingLogger struct {
+	messages []string
+}
+
+func (l *RecordingLogger) Log(msg string) {
+	l.messages = append(l.messages, msg)
+}
+
+func NewRecordingLogger() (Logger, error) {
+	return &RecordingLogger{}, nil
+}
+
+type Handler struct {
+	repo Repository
+	log  Logger
+}
+
+func NewHandler(repo Repository, log Logger) (*Handler, error) {
+	return &Handler{repo: repo, log: log}, nil
+}
+
+func (h *Handler) Handle(id int) string {
+	record := h.repo.Find(id)
+	h.log.Log("found " + record)
+	return record
+}
+
+func BadCtorForHandlerValueParam(simple SimpleStruct) (*Handler, error) {
+	return &Handler{}, nil
+}
+
+func BadCtorForHandlerPointerToInterfaceParam(repo *Repository) (*Handler, error) {
+	return &Handler{}, nil
+}
+
+type Store interface {
+	Get(key string) string
+}
+
+type PostgresStore struct {
+	dsn string
+}
+
+func (s *PostgresStore) Get(key string) string {
+	return s.dsn + "/" + key
+}
+
+func NewPostgresStore() (*PostgresStore, error) {
+	return &PostgresStore{dsn: "postgres://localhost"}, nil
+}
+
+type StoreConsumer struct {
+	store Store
+}
+
+func NewStoreConsumer(store Store) (*StoreConsumer, error) {
+	return &StoreConsumer{store: store}, nil
+}
+
+type Database struct {
+	dsn string
+}
+
+func NewDatabaseFactory(dsn string) func() (*Database, error) {
+	return func() (*Database, error) {
+		return &Database{dsn: dsn}, nil
+	}
+}
+
+type ReportService struct {
+	primary *Database
+	replica *Database
+}
+
+func NewReportService(primary *Database, replica *Database) (*ReportService, error) {
+	return &ReportService{primary: primary, replica: replica}, nil
+}
+
+type HealthCheck interface {
+	Check() error
+}
+
+type NamedHealthCheck struct {
+	name string
+}
+
+func (c *NamedHealthCheck) Check() error {
+	return nil
+}
+
+func NewHealthCheckFactory(name string) func() (HealthCheck, error) {
+	return func() (HealthCheck, error) {
+		return &NamedHealthCheck{name: name}, nil
+	}
+}
+
+type DatabaseHealthCheck struct{}
+
+func (c *DatabaseHealthCheck) Check() error {
+	return nil
+}
+
+func NewDatabaseHealthCheck() (*DatabaseHealthCheck, error) {
+	return &DatabaseHealthCheck{}, nil
+}
+
+type HealthReporter struct {
+	checks []HealthCheck
+}
+
+func NewHealthReporter(checks []HealthCheck) (*HealthReporter, error) {
+	return &HealthReporter{checks: checks}, nil
+}
+
+func NewHealthCheckNeedingReporter(reporter *HealthReporter) (HealthCheck, error) {
+	return &NamedHealthCheck{name: "reporter"}, nil
+}
+
+type Exporter interface {
+	Export() string
+}
+
+type NamedExporter struct {
+	name string
+}
+
+func (e *NamedExporter) Export() string {
+	return e.name
+}
+
+func NewExporterFactory(name string) func() (Exporter, error) {
+	return func() (Exporter, error) {
+		return &NamedExporter{name: name}, nil
+	}
+}
+
+type ExporterRegistry struct {
+	exporters map[string]Exporter
+}
+
+func NewExporterRegistry(exporters map[string]Exporter) (*ExporterRegistry, error) {
+	return &ExporterRegistry{exporters: exporters}, nil
+}
+
+func BadCtorForExporterRegistryIntKeys(exporters map[int]Exporter) (*ExporterRegistry, error) {
+	return &ExporterRegistry{}, nil
+}
+
+type MetricsExporter interface {
+	Record(name string)
+}
+
+type CountingMetricsExporter struct {
+	count int
+}
+
+func (e *CountingMetricsExporter) Record(name string) {
+	e.count++
+}
+
+func NewCountingMetricsExporter() (MetricsExporter, error) {
+	return &CountingMetricsExporter{}, nil
+}
+
+type InstrumentedService struct {
+	metrics gotainer.Optional[MetricsExporter]
+}
+
+func NewInstrumentedService(metrics gotainer.Optional[MetricsExporter]) (*InstrumentedService, error) {
+	return &InstrumentedService{metrics: metrics}, nil
+}
+
+func BadCtorForInstrumentedServiceOptionalValue(simple gotainer.Optional[SimpleStruct]) (*InstrumentedService, error) {
+	return &InstrumentedService{}, nil
+}
+
+type LazyCycleA struct {
+	b gotainer.Lazy[*LazyCycleB]
+}
+
+func NewLazyCycleA(b gotainer.Lazy[*LazyCycleB]) (*LazyCycleA, error) {
+	return &LazyCycleA{b: b}, nil
+}
+
+type LazyCycleB struct {
+	a *LazyCycleA
+}
+
+func NewLazyCycleB(a *LazyCycleA) (*LazyCycleB, error) {
+	return &LazyCycleB{a: a}, nil
+}
+
+func NewEagerLazyCycleA(b gotainer.Lazy[*LazyCycleB]) (*LazyCycleA, error) {
+	_, err := b.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &LazyCycleA{b: b}, nil
+}
+
+type ExpensiveService struct {
+	id int
+}
+
+func NewExpensiveServiceFactory(constructed *int) func() (*ExpensiveService, error) {
+	return func() (*ExpensiveService, error) {
+		*constructed++
+		return &ExpensiveService{id: *constructed}, nil
+	}
+}
+
+type LazyConsumer struct {
+	service gotainer.Lazy[*ExpensiveService]
+}
+
+func NewLazyConsumer(service gotainer.Lazy[*ExpensiveService]) (*LazyConsumer, error) {
+	return &LazyConsumer{service: service}, nil
+}
+
+type ProviderConsumer struct {
+	service gotainer.Provider[*ExpensiveService]
+}
+
+func NewProviderConsumer(service gotainer.Provider[*ExpensiveService]) (*ProviderConsumer, error) {
+	return &ProviderConsumer{service: service}, nil
+}
+
+type ReportDeps struct {
+	gotainer.In
+
+	Primary *Database       `inject:"name=primary"`
+	Replica *Database       `inject:"name=replica"`
+	Checks  []HealthCheck   `inject:"group"`
+	Metrics MetricsExporter `inject:"optional"`
+	Store   gotainer.Optional[Store]
+	unused  *Database
+}
+
+type DetailedReportService struct {
+	deps ReportDeps
+}
+
+func NewDetailedReportService(deps ReportDeps) (*DetailedReportService, error) {
+	return &DetailedReportService{deps: deps}, nil
+}
+
+type BadTagDeps struct {
+	gotainer.In
+
+	Primary *Database `inject:"primary"`
+}
+
+func BadCtorForDetailedReportServiceTag(deps BadTagDeps) (*DetailedReportService, error) {
+	return &DetailedReportService{}, nil
+}
+
+type BadGroupDeps struct {
+	gotainer.In
+
+	Primary *Database `inject:"group"`
+}
+
+func BadCtorForDetailedReportServiceGroup(deps BadGroupDeps) (*DetailedReportService, error) {
+	return &DetailedReportService{}, nil
+}
+
+type Migrator struct {
+	db *Database
+}
+
+type DatabaseHealth struct {
+	db *Database
+}
+
+func (h *DatabaseHealth) Check() error {
+	return nil
+}
+
+type DatabaseModule struct {
+	gotainer.Out
+
+	DB       *Database
+	Replica  *Database `inject:"name=replica"`
+	Migrator *Migrator
+	Health   HealthCheck `inject:"group"`
+	internal *Database
+}
+
+func NewDatabaseModuleFactory(constructed *int) func() (DatabaseModule, error) {
+	return func() (DatabaseModule, error) {
+		*constructed++
+		db := &Database{dsn: "primary-dsn"}
+		return DatabaseModule{
+			DB:       db,
+			Replica:  &Database{dsn: "replica-dsn"},
+			Migrator: &Migrator{db: db},
+			Health:   &DatabaseHealth{db: db},
+		}, nil
+	}
+}
+
+type ClosableModule struct {
+	gotainer.Out
+
+	Leaf *ClosableLeaf
+	Root *ClosableRoot
+}
+
+type BadValueModule struct {
+	gotainer.Out
+
+	DB Database
+}
+
+func NewBadValueModule() (BadValueModule, error) {
+	return BadValueModule{}, nil
+}
+
+func NewClosableModule(log *CloseLog) (*ClosableModule, error) {
+	leaf := &ClosableLeaf{log: log}
+	return &ClosableModule{Leaf: leaf, Root: &ClosableRoot{leaf: leaf}}, nil
+}
+
+type FrameworkHandler struct {
+	Primary *Database       `inject:""`
+	Replica *Database       `inject:"name=replica"`
+	Metrics MetricsExporter `inject:"optional"`
+	Checks  []HealthCheck   `inject:"group"`
+	Route   string
+}
+
+type BadFrameworkHandler struct {
+	Primary   *Database    `inject:""`
+	Analytics *Database    `inject:"name=analytics"`
+	Simple    SimpleStruct `inject:""`
+	private   *Database    `inject:""`
+}
+
+type contextKey struct{}
+
+type RequestInfo struct {
+	requestID any
+}
+
+func NewRequestInfo(ctx context.Context) (*RequestInfo, error) {
+	return &RequestInfo{requestID: ctx.Value(contextKey{})}, nil
+}
+
+type RequestHandler struct {
+	info *RequestInfo
+}
+
+func NewRequestHandlerFactory(calls *int) func(context.Context, *RequestInfo) (*RequestHandler, error) {
+	return func(ctx context.Context, info *RequestInfo) (*RequestHandler, error) {
+		*calls++
+		return &RequestHandler{info: info}, nil
+	}
+}
+
+func BadCtorForRequestInfoContextNotFirst(s *SimpleStruct, ctx context.Context) (*RequestInfo, error) {
+	return &RequestInfo{}, nil
+}
+
+type StartLog struct {
+	mu    sync.Mutex
+	built []string
+}
+
+func (l *StartLog) record(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.built = append(l.built, name)
+}
+
+func (l *StartLog) Built() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.built...)
+}
+
+type Config struct{}
+
+func NewConfigFactory(log *StartLog) func() (*Config, error) {
+	return func() (*Config, error) {
+		log.record("config")
+		return &Config{}, nil
+	}
+}
+
+func NewFailingConfigFactory(err error) func() (*Config, error) {
+	return func() (*Config, error) {
+		return nil, err
+	}
+}
+
+type Templates struct {
+	config *Config
+}
+
+func NewTemplatesFactory(log *StartLog) func(*Config) (*Templates, error) {
+	return func(config *Config) (*Templates, error) {
+		log.record("templates")
+		return &Templates{config: config}, nil
+	}
+}
+
+type Cache struct {
+	templates *Templates
+}
+
+func NewCacheFactory(log *StartLog) func(*Templates) (*Cache, error) {
+	return func(templates *Templates) (*Cache, error) {
+		log.record("cache")
+		return &Cache{templates: templates}, nil
+	}
+}
+
+type Mailer struct{}
+
+func NewMailerFactory(log *StartLog) func() (*Mailer, error) {
+	return func() (*Mailer, error) {
+		log.record("mailer")
+		return &Mailer{}, nil
+	}
+}
+
+func NewFailingMailerFactory(err error) func() (*Mailer, error) {
+	return func() (*Mailer, error) {
+		return nil, err
+	}
+}
+
+// StartBarrier holds back each ctor waiting at it until n ctors are waiting at once.
+type StartBarrier struct {
+	wg sync.WaitGroup
+}
+
+func NewStartBarrier(n int) *StartBarrier {
+	barrier := &StartBarrier{}
+	barrier.wg.Add(n)
+	return barrier
+}
+
+func (b *StartBarrier) wait() error {
+	b.wg.Done()
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(time.Second):
+		return errors.New("timed out waiting for concurrent construction")
+	}
+}
+
+type ConfigParser struct{}
+
+func NewConfigParserFactory(barrier *StartBarrier) func() (*ConfigParser, error) {
+	return func() (*ConfigParser, error) {
+		return &ConfigParser{}, barrier.wait()
+	}
+}
+
+type TemplateCompiler struct{}
+
+func NewTemplateCompilerFactory(barrier *StartBarrier) func() (*TemplateCompiler, error) {
+	return func() (*TemplateCompiler, error) {
+		return &TemplateCompiler{}, barrier.wait()
+	}
+}
+
+type SelfReportingExporter struct {
+	metrics gotainer.Optional[MetricsExporter]
+}
+
+func (e *SelfReportingExporter) Record(name string) {
+}
+
+func NewSelfReportingExporter(metrics gotainer.Optional[MetricsExporter]) (*SelfReportingExporter, error) {
+	return &SelfReportingExporter{metrics: metrics}, nil
+}
+
+func NewCountingMetricsExporterStruct() (*CountingMetricsExporter, error) {
+	return &CountingMetricsExporter{}, nil
+}
+
+func NewNilDatabaseModule() (*DatabaseModule, error) {
+	return nil, nil
+}
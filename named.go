@@ -0,0 +1,104 @@
+package gotainer
+
+import (
+	"context"
+	"reflect"
+)
+
+// RegisterTransientNamed registers a transient T under name, alongside any other registrations of T.
+func RegisterTransientNamed[T any, Fn any](container *Container, name string, ctor Fn, opts ...RegisterOption) error {
+	return register(container, keyOf[T](name), ctor, LifetimeTransient, opts)
+}
+
+// RegisterScopedNamed registers a scoped T under name, alongside any other registrations of T.
+func RegisterScopedNamed[T any, Fn any](container *Container, name string, ctor Fn, opts ...RegisterOption) error {
+	return register(container, keyOf[T](name), ctor, LifetimeScoped, opts)
+}
+
+// RegisterSingletonNamed registers a singleton T under name, alongside any other registrations of T.
+func RegisterSingletonNamed[T any, Fn any](container *Container, name string, ctor Fn, opts ...RegisterOption) error {
+	return register(container, keyOf[T](name), ctor, LifetimeSingleton, opts)
+}
+
+func MustRegisterTransientNamed[T any, Fn any](container *Container, name string, ctor Fn, opts ...RegisterOption) {
+	err := RegisterTransientNamed[T, Fn](container, name, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterScopedNamed[T any, Fn any](container *Container, name string, ctor Fn, opts ...RegisterOption) {
+	err := RegisterScopedNamed[T, Fn](container, name, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func MustRegisterSingletonNamed[T any, Fn any](container *Container, name string, ctor Fn, opts ...RegisterOption) {
+	err := RegisterSingletonNamed[T, Fn](container, name, ctor, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ResolveNamed resolves the T registered under name, the empty name resolves the unnamed registration.
+func ResolveNamed[T any](resolver Resolver, name string) (*T, error) {
+	return resolvePointer[T](context.Background(), resolver, keyOf[T](name))
+}
+
+func MustResolveNamed[T any](resolver Resolver, name string) *T {
+	res, err := ResolveNamed[T](resolver, name)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// ResolveInterfaceNamed resolves the T registered under name, the empty name resolves the unnamed registration.
+func ResolveInterfaceNamed[T any](resolver Resolver, name string) (T, error) {
+	return resolveInterface[T](context.Background(), resolver, keyOf[T](name))
+}
+
+func MustResolveInterfaceNamed[T any](resolver Resolver, name string) T {
+	res, err := ResolveInterfaceNamed[T](resolver, name)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// WithParamNames resolves the ctor's parameters by name, in parameter order. The empty name
+// resolves the unnamed registration, and parameters beyond the given names are resolved unnamed.
+func WithParamNames(names ...string) RegisterOption {
+	return func(options *registrationOptions) {
+		options.paramNames = names
+	}
+}
+
+// resolvePointer resolves k with ctx, handing back a pointer whether T is a struct or an interface.
+func resolvePointer[T any](ctx context.Context, resolver Resolver, k key) (*T, error) {
+	val, err := resolver.resolve(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	if k.t.Kind() == reflect.Interface {
+		// interface registrations hold the interface itself, hand back a pointer to a copy of it
+		res := valueAs[T](val)
+		return &res, nil
+	}
+	return valueAs[*T](val), nil
+}
+
+// resolveInterface resolves k with ctx, handing back T itself. Struct registrations are held as a
+// pointer, a copy of the struct is handed back.
+func resolveInterface[T any](ctx context.Context, resolver Resolver, k key) (T, error) {
+	var defaultVal T
+	val, err := resolver.resolve(ctx, k)
+	if err != nil {
+		return defaultVal, err
+	}
+	if k.t.Kind() != reflect.Interface {
+		return valueAs[T](val.Elem()), nil
+	}
+	return valueAs[T](val), nil
+}
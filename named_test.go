@@ -0,0 +1,171 @@
+package gotainer_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestRegisterNamed_SameTypeDifferentNames_ResolvesEachRegistration(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonNamed[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+	err := gotainer.RegisterSingletonNamed[Database](c, "replica", NewDatabaseFactory("replica-dsn"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	primary, err := gotainer.ResolveNamed[Database](c, "primary")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	replica := gotainer.MustResolveNamed[Database](c, "replica")
+
+	if primary.dsn != "primary-dsn" || replica.dsn != "replica-dsn" {
+		t.Errorf("expected each name to resolve its own registration, got %q and %q", primary.dsn, replica.dsn)
+	}
+	if primary != gotainer.MustResolveNamed[Database](c, "primary") {
+		t.Error("expected named singleton to be constructed once")
+	}
+}
+
+func TestRegisterNamed_NamedAndUnnamed_AreSeparateRegistrations(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Database](c, NewDatabaseFactory("default-dsn"))
+	gotainer.MustRegisterSingletonNamed[Database](c, "replica", NewDatabaseFactory("replica-dsn"))
+
+	if gotainer.MustResolve[Database](c).dsn != "default-dsn" {
+		t.Error("expected unnamed resolve to return the unnamed registration")
+	}
+	if gotainer.MustResolveNamed[Database](c, "").dsn != "default-dsn" {
+		t.Error("expected the empty name to resolve the unnamed registration")
+	}
+}
+
+func TestRegisterNamed_DuplicateName_ReturnsDuplicateRegistrationError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonNamed[Database](c, "replica", NewDatabaseFactory("replica-dsn"))
+
+	err := gotainer.RegisterTransientNamed[Database](c, "replica", NewDatabaseFactory("other-dsn"))
+
+	duplicateErr := &gotainer.DuplicateRegistrationError{}
+	if !errors.As(err, &duplicateErr) {
+		t.Errorf("expected error to be DuplicateRegistrationError, got %v", err)
+		return
+	}
+	if duplicateErr.TypeName != "github.com/BlindGarret/gotainer_test.Database[replica]" {
+		t.Errorf("expected type name to include the name, got %q", duplicateErr.TypeName)
+	}
+}
+
+func TestResolveNamed_UnknownName_ReturnsTypeNotRegisteredErrorSuggestingNames(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonNamed[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+	gotainer.MustRegisterSingletonNamed[Database](c, "replica", NewDatabaseFactory("replica-dsn"))
+
+	_, err := gotainer.ResolveNamed[Database](c, "analytics")
+
+	notRegisteredErr := &gotainer.TypeNotRegisteredError{}
+	if !errors.As(err, &notRegisteredErr) {
+		t.Errorf("expected error to be TypeNotRegisteredError, got %v", err)
+		return
+	}
+	expected := []string{
+		"github.com/BlindGarret/gotainer_test.Database[primary]",
+		"github.com/BlindGarret/gotainer_test.Database[replica]",
+	}
+	if !slices.Equal(notRegisteredErr.Suggestions, expected) {
+		t.Errorf("expected suggestions %v, got %v", expected, notRegisteredErr.Suggestions)
+	}
+}
+
+func TestWithParamNames_NamedParams_InjectsNamedRegistrations(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonNamed[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+	gotainer.MustRegisterSingletonNamed[Database](c, "replica", NewDatabaseFactory("replica-dsn"))
+	err := gotainer.RegisterTransient[ReportService](c, NewReportService, gotainer.WithParamNames("primary", "replica"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	service := gotainer.MustResolve[ReportService](c)
+
+	if service.primary.dsn != "primary-dsn" || service.replica.dsn != "replica-dsn" {
+		t.Errorf("expected params to be injected by name, got %q and %q", service.primary.dsn, service.replica.dsn)
+	}
+}
+
+func TestWithParamNames_MissingName_ReturnsPrefetchArgumentError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonNamed[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+
+	err := gotainer.RegisterTransient[ReportService](c, NewReportService, gotainer.WithParamNames("primary", "replica"))
+
+	prefetchErr := &gotainer.PrefetchArgumentError{}
+	if !errors.As(err, &prefetchErr) {
+		t.Errorf("expected error to be PrefetchArgumentError, got %v", err)
+		return
+	}
+	if prefetchErr.DependencyName != "Database[replica]" {
+		t.Errorf("expected missing dependency Database[replica], got %q", prefetchErr.DependencyName)
+	}
+}
+
+func TestWithParamNames_TooManyNames_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterTransient[ReportService](c, NewReportService, gotainer.WithParamNames("primary", "replica", "analytics"))
+
+	if !errors.As(err, new(*gotainer.ConstructorMismatchError)) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestValidate_MissingNamedDependency_ReportsMissingKey(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterTransient[ReportService](c, NewReportService, gotainer.WithParamNames("primary", "replica"))
+	gotainer.MustRegisterSingletonNamed[Database](c, "primary", NewDatabaseFactory("primary-dsn"))
+
+	err := c.Validate()
+
+	missingErr := &gotainer.MissingDependencyError{}
+	if !errors.As(err, &missingErr) {
+		t.Errorf("expected error to contain MissingDependencyError, got %v", err)
+		return
+	}
+	if missingErr.ParentTypeName != "ReportService" || missingErr.DependencyName != "Database[replica]" {
+		t.Errorf("expected ReportService to be missing Database[replica], got %s missing %s", missingErr.ParentTypeName, missingErr.DependencyName)
+	}
+}
+
+func TestAs_NamedRegistration_BindsInterfaceUnderSameName(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingletonNamed[PostgresStore](c, "replica", NewPostgresStore, gotainer.As[Store]())
+
+	store, err := gotainer.ResolveInterfaceNamed[Store](c, "replica")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if store != Store(gotainer.MustResolveNamed[PostgresStore](c, "replica")) {
+		t.Error("expected named interface binding to resolve the named singleton")
+	}
+	if _, err := gotainer.ResolveInterface[Store](c); err == nil {
+		t.Error("expected the unnamed interface to remain unregistered")
+	}
+}
+
+func TestRegisterInstanceNamed_NamedInstance_ResolvesSameInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	db := &Database{dsn: "analytics-dsn"}
+	gotainer.MustRegisterInstanceNamed[Database](c, "analytics", db)
+
+	if gotainer.MustResolveNamed[Database](c, "analytics") != db {
+		t.Error("expected named instance to be resolved")
+	}
+}
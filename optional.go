@@ -0,0 +1,53 @@
+package gotainer
+
+import "reflect"
+
+var optionalType = reflect.TypeOf((*optional)(nil)).Elem()
+
+// Optional is injected in place of T when T may not be registered, the ctor is still called with
+// an absent Optional when it is not. T is anything a ctor parameter could otherwise be.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// Value returns the resolved dependency, or the zero value of T when it is absent.
+func (o Optional[T]) Value() T {
+	return o.value
+}
+
+// Present reports whether the dependency was registered and resolved.
+func (o Optional[T]) Present() bool {
+	return o.present
+}
+
+func (o Optional[T]) injectedType() reflect.Type {
+	return typeOf[T]()
+}
+
+func (o *Optional[T]) set(val reflect.Value) {
+	o.value = valueAs[T](val)
+	o.present = true
+}
+
+// optional is implemented by every Optional, letting parameters be recognised without knowing T.
+type optional interface {
+	injectedType() reflect.Type
+	set(val reflect.Value)
+}
+
+func isOptional(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(optionalType)
+}
+
+// optionalOf returns the T of the Optional[T] type t.
+func optionalOf(t reflect.Type) reflect.Type {
+	return reflect.New(t).Interface().(optional).injectedType()
+}
+
+// newOptional returns a present Optional of type t holding val.
+func newOptional(t reflect.Type, val reflect.Value) reflect.Value {
+	opt := reflect.New(t)
+	opt.Interface().(optional).set(val)
+	return opt.Elem()
+}
@@ -0,0 +1,109 @@
+package gotainer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestOptional_DependencyRegistered_InjectsPresentValue(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[MetricsExporter](c, NewCountingMetricsExporter)
+	gotainer.MustRegisterTransient[InstrumentedService](c, NewInstrumentedService)
+
+	service := gotainer.MustResolve[InstrumentedService](c)
+
+	if !service.metrics.Present() {
+		t.Error("expected registered dependency to be present")
+		return
+	}
+	if service.metrics.Value() != gotainer.MustResolveInterface[MetricsExporter](c) {
+		t.Error("expected optional to hold the registered singleton")
+	}
+}
+
+func TestOptional_DependencyNotRegistered_InjectsAbsentValue(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterTransient[InstrumentedService](c, NewInstrumentedService)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	service, err := gotainer.Resolve[InstrumentedService](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if service.metrics.Present() {
+		t.Error("expected missing dependency to be absent")
+	}
+	if service.metrics.Value() != nil {
+		t.Error("expected absent optional to hold the zero value")
+	}
+}
+
+func TestOptional_DependencyRegisteredLater_IsPresent(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterTransient[InstrumentedService](c, NewInstrumentedService)
+	gotainer.MustRegisterSingleton[MetricsExporter](c, NewCountingMetricsExporter)
+
+	service := gotainer.MustResolve[InstrumentedService](c)
+
+	if !service.metrics.Present() {
+		t.Error("expected dependency registered after the consumer to be present")
+	}
+}
+
+func TestOptional_DependencyFails_ReturnsError(t *testing.T) {
+	c := gotainer.NewContainer()
+	expected := errors.New("exporter unavailable")
+	gotainer.MustRegisterSingleton[MetricsExporter](c, func() (MetricsExporter, error) { return nil, expected })
+	gotainer.MustRegisterTransient[InstrumentedService](c, NewInstrumentedService)
+
+	_, err := gotainer.Resolve[InstrumentedService](c)
+
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error from registered optional dependency, got %v", err)
+	}
+}
+
+func TestOptional_Validate_DoesNotReportMissingDependency(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterTransient[InstrumentedService](c, NewInstrumentedService)
+
+	err := c.Validate()
+
+	if err != nil {
+		t.Errorf("expected missing optional dependency to be valid, got %v", err)
+	}
+}
+
+func TestOptional_Invoke_InjectsAbsentValue(t *testing.T) {
+	c := gotainer.NewContainer()
+	called := false
+
+	err := gotainer.Invoke(c, func(metrics gotainer.Optional[MetricsExporter]) {
+		called = !metrics.Present()
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !called {
+		t.Error("expected fn to be invoked with an absent optional")
+	}
+}
+
+func TestOptional_ValueType_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterTransient[InstrumentedService](c, BadCtorForInstrumentedServiceOptionalValue)
+
+	if !errors.As(err, new(*gotainer.ConstructorMismatchError)) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package gotainer
+
+import "reflect"
+
+// ContainerOption customises a Container when it is created.
+type ContainerOption func(*Container)
+
+// WithDeferredValidation allows types to be registered in any order, dependencies are not checked
+// at registration and the graph must instead be checked with Container.Validate once every type
+// has been registered.
+func WithDeferredValidation() ContainerOption {
+	return func(container *Container) {
+		container.deferValidation = true
+	}
+}
+
+// RegisterOption customises a single registration.
+type RegisterOption func(*registrationOptions)
+
+type registrationOptions struct {
+	cleanup     func(instance any) error
+	cleanupType reflect.Type
+	bindings    []reflect.Type
+	groups      []membership
+	paramNames  []string
+	unowned     bool
+	eager       bool
+}
+
+func newRegistrationOptions(opts []RegisterOption) *registrationOptions {
+	options := &registrationOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithCleanup registers fn to tear down a constructed singleton or scoped instance when its
+// Container or Scope is closed, it is used in place of io.Closer. V must be the type returned
+// by the ctor.
+func WithCleanup[V any](fn func(V) error) RegisterOption {
+	return func(options *registrationOptions) {
+		options.cleanupType = reflect.TypeOf((*V)(nil)).Elem()
+		options.cleanup = func(instance any) error {
+			return fn(instance.(V))
+		}
+	}
+}
+
+// WithoutCleanup leaves teardown of the registered type to its owner, neither io.Closer nor a
+// cleanup registered WithCleanup is called when the Container or Scope is closed. This is
+// typically used with RegisterInstance for instances managed elsewhere. Ctors which return a
+// cleanup cannot be registered WithoutCleanup.
+func WithoutCleanup() RegisterOption {
+	return func(options *registrationOptions) {
+		options.unowned = true
+	}
+}
+
+// StartOption customises a single Container.Start or Container.InitSingletons.
+type StartOption func(*startOptions)
+
+type startOptions struct {
+	workers int
+}
+
+func newStartOptions(opts []StartOption) *startOptions {
+	options := &startOptions{workers: 1}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithWorkers constructs up to n singletons at once, each still only once the singletons it depends
+// on have been constructed. Singletons are constructed one at a time by default, n below 1 is
+// treated as 1.
+func WithWorkers(n int) StartOption {
+	return func(options *startOptions) {
+		options.workers = max(n, 1)
+	}
+}
+
+// Eager marks a singleton to be constructed by Container.Start rather than on its first resolve.
+func Eager() RegisterOption {
+	return func(options *registrationOptions) {
+		options.eager = true
+	}
+}
+
+func testOptions(fnType reflect.Type, lifetime Lifetime, options *registrationOptions) error {
+	if options.cleanupType != nil && options.cleanupType != fnType.Out(0) {
+		return NewConstructorMismatchError("cleanup must accept the type returned by the ctor")
+	}
+	if options.cleanup != nil && fnType.NumOut() == 3 {
+		return NewConstructorMismatchError("ctor which returns a cleanup cannot also register one with WithCleanup")
+	}
+	if options.unowned && fnType.NumOut() == 3 {
+		return NewConstructorMismatchError("ctor which returns a cleanup cannot be registered WithoutCleanup")
+	}
+	if options.eager && lifetime != LifetimeSingleton {
+		return NewConstructorMismatchError("only singletons can be constructed eagerly")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package gotainer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+var outType = reflect.TypeOf(Out{})
+
+// Out is embedded in a struct to make it a result object, letting a single ctor provide several
+// types. The ctor returns the result object, by value or as a pointer, and is registered like any
+// other type. Each exported field is then registered under its own type following the lifetime of
+// the result object. Fields must be pointers or interfaces and may be tagged `inject:"name=replica"`
+// to be registered under a name, or `inject:"group"` to join the group of their type instead.
+type Out struct{}
+
+func isResultObject(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && field.Type == outType {
+			return true
+		}
+	}
+	return false
+}
+
+// isResultField reports whether a field of a result object is registered.
+func isResultField(field reflect.StructField) bool {
+	return field.IsExported() && !(field.Anonymous && field.Type == outType)
+}
+
+// testResultObject checks every field of the result object can be registered, deps are the
+// dependencies of its ctor. Callers must hold the container lock.
+func testResultObject(container *Container, result key, deps []dependency) error {
+	t := result.t
+	seen := make(map[key]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isResultField(field) {
+			continue
+		}
+		tag, err := parseInjectTag(field.Tag.Get("inject"))
+		switch {
+		case err != nil:
+			return NewConstructorMismatchError(fmt.Sprintf("field %s of %s has an invalid inject tag: %v", field.Name, typeName(t), err))
+		case tag.optional:
+			return NewConstructorMismatchError(fmt.Sprintf("field %s of %s is a result and cannot be optional", field.Name, typeName(t)))
+		case tag.group && tag.name != "":
+			return NewConstructorMismatchError(fmt.Sprintf("field %s of %s joins a group and cannot be registered by name", field.Name, typeName(t)))
+		case !isInjectable(field.Type):
+			return NewConstructorMismatchError(fmt.Sprintf("field %s of %s must be a pointer or interface", field.Name, typeName(t)))
+		}
+
+		fieldType := dependencyType(field.Type)
+		if tag.group {
+			// the ctor may already depend on the group, joining it would close a cycle
+			group := groupKey(fieldType)
+			if container.deferValidation {
+				continue
+			}
+			if cycle := findCycle(container, group, deps); cycle != nil {
+				return newCircularDependencyError(append([]key{group, result}, cycle[1:]...))
+			}
+			continue
+		}
+		k := key{t: fieldType, name: tag.name}
+		if isRegistered(container, k) || seen[k] {
+			return NewDuplicateRegistrationError(k.qualifiedName())
+		}
+		seen[k] = true
+	}
+	return nil
+}
+
+// addResultFields registers every field of the result object, callers must hold the container lock.
+func addResultFields(container *Container, result key) {
+	for i := 0; i < result.t.NumField(); i++ {
+		field := result.t.Field(i)
+		if !isResultField(field) {
+			continue
+		}
+		tag, _ := parseInjectTag(field.Tag.Get("inject"))
+		fieldType := dependencyType(field.Type)
+		if tag.group {
+			member := key{t: fieldType, member: nextMember(container, fieldType)}
+			addResultField(container, member, result, i)
+			addGroupMember(container, membership{group: groupKey(fieldType)}, member)
+			continue
+		}
+		addResultField(container, key{t: fieldType, name: tag.name}, result, i)
+	}
+}
+
+// addResultField registers k as the field at index of result, callers must hold the container lock.
+// Like bindings, fields hold no instance of their own and every resolve is delegated to the result
+// object, so they are treated as transient.
+func addResultField(container *Container, k key, result key, index int) {
+	container.registrations[k] = &registration{
+		lifetime: LifetimeTransient,
+		ctor: func(res *resolution) (reflect.Value, error) {
+			val, err := resolveNoReflect(container, result, res)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if val.IsNil() {
+				// a ctor may return a nil result object, each of its fields is then the zero value
+				return reflect.Zero(val.Type().Elem().Field(index).Type), nil
+			}
+			return val.Elem().Field(index), nil
+		},
+		deps: []dependency{{key: result}},
+	}
+	container.order = append(container.order, k)
+}
+
+// wrapResultCtor stores a result object returned by value as a pointer.
+func wrapResultCtor(ctor ctorFunc) ctorFunc {
+	return func(res *resolution) (reflect.Value, error) {
+		val, err := ctor(res)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+		return ptr, nil
+	}
+}
+
+// closeResultFields returns a teardown closing every io.Closer field of a result object in reverse
+// field order, or nil if there are none.
+func closeResultFields(result reflect.Value) func() error {
+	var closers []io.Closer
+	for i := 0; i < result.NumField(); i++ {
+		if !isResultField(result.Type().Field(i)) {
+			continue
+		}
+		field := result.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		if closer, ok := field.Interface().(io.Closer); ok {
+			closers = append(closers, closer)
+		}
+	}
+	if len(closers) == 0 {
+		return nil
+	}
+	return func() error {
+		var errs []error
+		for i := len(closers) - 1; i >= 0; i-- {
+			if err := closers[i].Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,156 @@
+package gotainer_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestOut_SingletonResultObject_RegistersEachField(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	err := gotainer.RegisterSingleton[DatabaseModule](c, NewDatabaseModuleFactory(&constructed))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db := gotainer.MustResolve[Database](c)
+	replica := gotainer.MustResolveNamed[Database](c, "replica")
+	migrator := gotainer.MustResolve[Migrator](c)
+	checks := gotainer.MustResolveAllInterface[HealthCheck](c)
+
+	if constructed != 1 {
+		t.Errorf("expected the ctor to be called once for every field, got %d", constructed)
+	}
+	if db.dsn != "primary-dsn" || replica.dsn != "replica-dsn" {
+		t.Errorf("expected fields to be registered under their names, got %q and %q", db.dsn, replica.dsn)
+	}
+	if migrator.db != db {
+		t.Error("expected fields of a singleton to come from the same result")
+	}
+	if len(checks) != 1 || checks[0].(*DatabaseHealth).db != db {
+		t.Error("expected group field to join the group")
+	}
+	if gotainer.MustResolve[Database](c) != db {
+		t.Error("expected fields to follow the singleton lifetime")
+	}
+}
+
+func TestOut_TransientResultObject_ConstructsPerResolve(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterTransient[DatabaseModule](c, NewDatabaseModuleFactory(&constructed))
+
+	first := gotainer.MustResolve[Database](c)
+	second := gotainer.MustResolve[Database](c)
+
+	if constructed != 2 || first == second {
+		t.Errorf("expected fields to follow the transient lifetime, got %d constructions", constructed)
+	}
+}
+
+func TestOut_ScopedResultObject_SharesWithinScope(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterScoped[DatabaseModule](c, NewDatabaseModuleFactory(&constructed))
+	scope := c.NewScope()
+
+	db := gotainer.MustResolve[Database](scope)
+	migrator := gotainer.MustResolve[Migrator](scope)
+
+	if constructed != 1 || migrator.db != db {
+		t.Errorf("expected fields to share a result within the scope, got %d constructions", constructed)
+	}
+	if _, err := gotainer.Resolve[Database](c); !errors.As(err, new(*gotainer.ScopeRequiredError)) {
+		t.Errorf("expected fields of a scoped result to require a scope, got %v", err)
+	}
+}
+
+func TestOut_FieldAlreadyRegistered_ReturnsDuplicateRegistrationError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Migrator](c, func() (*Migrator, error) { return &Migrator{}, nil })
+	constructed := 0
+
+	err := gotainer.RegisterSingleton[DatabaseModule](c, NewDatabaseModuleFactory(&constructed))
+
+	if !errors.As(err, new(*gotainer.DuplicateRegistrationError)) {
+		t.Errorf("expected error to be DuplicateRegistrationError, got %v", err)
+		return
+	}
+	if _, err := gotainer.Resolve[Database](c); err == nil {
+		t.Error("expected no field to be registered when the result object is rejected")
+	}
+}
+
+func TestOut_ValueField_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterSingleton[BadValueModule](c, NewBadValueModule)
+
+	if !errors.As(err, new(*gotainer.ConstructorMismatchError)) {
+		t.Errorf("expected error to be ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestOut_FieldDependency_InjectsField(t *testing.T) {
+	c := gotainer.NewContainer()
+	constructed := 0
+	gotainer.MustRegisterSingleton[DatabaseModule](c, NewDatabaseModuleFactory(&constructed))
+	err := gotainer.RegisterTransient[ReportService](c, NewReportService, gotainer.WithParamNames("", "replica"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	service := gotainer.MustResolve[ReportService](c)
+
+	if service.primary.dsn != "primary-dsn" || service.replica.dsn != "replica-dsn" {
+		t.Errorf("expected result fields to be injected, got %q and %q", service.primary.dsn, service.replica.dsn)
+	}
+}
+
+func TestOut_NilResultObject_ResolvesZeroFields(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[DatabaseModule](c, NewNilDatabaseModule)
+
+	db, err := gotainer.Resolve[Database](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	health, err := gotainer.ResolveAllInterface[HealthCheck](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if db != nil {
+		t.Errorf("expected a field of a nil result object to resolve to nil, got %v", db)
+	}
+	if len(health) != 1 || health[0] != nil {
+		t.Errorf("expected the group field of a nil result object to resolve to nil, got %v", health)
+	}
+}
+
+func TestOut_Close_ClosesFieldsInReverseOrder(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[CloseLog](c, NewCloseLog)
+	gotainer.MustRegisterSingleton[ClosableModule](c, NewClosableModule)
+	gotainer.MustResolve[ClosableRoot](c)
+	log := gotainer.MustResolve[CloseLog](c)
+
+	err := c.Close(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{"ClosableRoot", "ClosableLeaf"}
+	if !slices.Equal(log.closed, expected) {
+		t.Errorf("expected fields to be closed in reverse order %v, got %v", expected, log.closed)
+	}
+}
@@ -0,0 +1,220 @@
+package gotainer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+const invalidParamReason = "must be a pointer to a registered type, a registered interface or a slice or map of either"
+
+// dependency is an edge of the dependency graph, from a registration to a key it resolves.
+type dependency struct {
+	key key
+	// optional dependencies are injected as absent when their key is not registered
+	optional bool
+	// deferred dependencies are resolved by a Lazy or Provider after construction, so they cannot form cycles
+	deferred bool
+}
+
+// param describes how a single ctor parameter is injected.
+type param struct {
+	t   reflect.Type
+	dep dependency
+	// fields are injected in place of dep when t is a parameter object
+	fields []param
+	// field is the index of the param within its parameter object
+	field int
+}
+
+// paramsOf describes each parameter of funcType, the first len(names) parameters are resolved by
+// name. Parameters must already have been checked by findInvalidParam.
+func paramsOf(funcType reflect.Type, names []string) []param {
+	params := make([]param, funcType.NumIn())
+	for i := range params {
+		var name string
+		if i < len(names) {
+			name = names[i]
+		}
+		params[i] = newParam(funcType.In(i), name)
+	}
+	return params
+}
+
+// newParam describes a parameter of type t resolved by name. Slice and map parameters receive a
+// group, Optional, Lazy and Provider parameters the type they wrap, and parameter objects each of
+// their fields.
+func newParam(t reflect.Type, name string) param {
+	p := param{t: t}
+	if isParamObject(t) {
+		p.fields = objectParams(t)
+		return p
+	}
+	injected := t
+	switch {
+	case isOptional(t):
+		p.dep.optional = true
+		injected = optionalOf(t)
+	case isDeferred(t):
+		p.dep.deferred = true
+		injected = deferredOf(t)
+	}
+	p.dep.key = key{t: dependencyType(injected), name: name, group: isGroupParam(injected)}
+	return p
+}
+
+func dependenciesOfParams(params []param) []dependency {
+	var deps []dependency
+	for _, p := range params {
+		if p.t == contextType {
+			continue
+		}
+		if isParamObject(p.t) {
+			deps = append(deps, dependenciesOfParams(p.fields)...)
+			continue
+		}
+		deps = append(deps, p.dep)
+	}
+	return deps
+}
+
+// injector resolves the parameters of a single call.
+type injector struct {
+	// ctx is injected into context.Context parameters
+	ctx       context.Context
+	container *Container
+	resolve   func(k key) (reflect.Value, error)
+	// resolveDeferred is used by Lazy and Provider, which may resolve long after the call returned
+	resolveDeferred func(k key) (reflect.Value, error)
+}
+
+// newInjector returns an injector resolving each parameter as a resolve of its own from resolver.
+func newInjector(ctx context.Context, resolver Resolver) *injector {
+	return &injector{
+		ctx:       ctx,
+		container: resolver.root(),
+		resolve: func(k key) (reflect.Value, error) {
+			return resolver.resolve(ctx, k)
+		},
+		resolveDeferred: func(k key) (reflect.Value, error) {
+			return resolver.resolve(context.Background(), k)
+		},
+	}
+}
+
+// inject resolves the value passed to the parameter.
+func (p param) inject(inj *injector) (reflect.Value, error) {
+	if p.t == contextType {
+		return reflect.ValueOf(&inj.ctx).Elem(), nil
+	}
+
+	if isParamObject(p.t) {
+		obj := reflect.New(p.t).Elem()
+		for _, field := range p.fields {
+			val, err := field.inject(inj)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			obj.Field(field.field).Set(val)
+		}
+		return obj, nil
+	}
+
+	if p.dep.deferred {
+		return newDeferred(p.t, func() (reflect.Value, error) {
+			return inj.resolveDeferred(p.dep.key)
+		}), nil
+	}
+
+	if p.dep.optional {
+		inj.container.mu.RLock()
+		registered := isRegistered(inj.container, p.dep.key)
+		inj.container.mu.RUnlock()
+		if !registered {
+			return reflect.Zero(p.t), nil
+		}
+	}
+
+	val, err := inj.resolve(p.dep.key)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if isOptional(p.t) {
+		return newOptional(p.t, val), nil
+	}
+	return val, nil
+}
+
+// resolveArgs resolves every parameter, ready to be passed to reflect.Value.Call.
+func resolveArgs(inj *injector, params []param) ([]reflect.Value, error) {
+	vals := make([]reflect.Value, len(params))
+	for i, p := range params {
+		resolvedInput, err := p.inject(inj)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = resolvedInput
+	}
+	return vals, nil
+}
+
+// findInvalidParam returns why the first parameter which can never be injected is invalid. Struct
+// registrations are injected as pointers, interface registrations as the interface itself and
+// groups as a slice or string keyed map of either, so every other kind of parameter, including
+// pointers to interfaces, is rejected. Any of these may be wrapped in an Optional, Lazy or
+// Provider, or gathered into a parameter object. The first parameter may instead be a
+// context.Context, which receives the context of the resolve.
+func findInvalidParam(funcType reflect.Type) (string, bool) {
+	for i := 0; i < funcType.NumIn(); i++ {
+		input := funcType.In(i)
+		if input == contextType {
+			if i != 0 {
+				return "parameter context.Context must be the first parameter", true
+			}
+			continue
+		}
+		if isParamObject(input) {
+			if reason, ok := findInvalidField(input); ok {
+				return "parameter " + reason, true
+			}
+			continue
+		}
+		if !isValidParam(input) {
+			return fmt.Sprintf("parameter %s %s", input, invalidParamReason), true
+		}
+	}
+	return "", false
+}
+
+func isValidParam(t reflect.Type) bool {
+	switch {
+	case isOptional(t):
+		t = optionalOf(t)
+	case isDeferred(t):
+		t = deferredOf(t)
+	}
+	if t.Kind() == reflect.Map && t.Key() != stringType {
+		return false
+	}
+	if isGroupParam(t) {
+		t = t.Elem()
+	}
+	return isInjectable(t)
+}
+
+func isGroupParam(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Map
+}
+
+func isInjectable(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface || (t.Kind() == reflect.Ptr && t.Elem().Kind() != reflect.Interface)
+}
+
+// dependencyType returns the registration key for a ctor parameter, pointer parameters
+// are registered under the type they point to.
+func dependencyType(input reflect.Type) reflect.Type {
+	if input.Kind() == reflect.Ptr {
+		return input.Elem()
+	}
+	return input
+}
@@ -0,0 +1,68 @@
+package gotainer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Populate injects the fields of an existing struct, target must be a pointer to it. Only fields
+// tagged `inject:""` are injected, following the same rules as ctor parameters. The tag may be
+// `inject:"name=replica"` to resolve a named registration or `inject:"optional"` to leave the
+// field untouched when not registered. Every field which can be resolved is injected, the rest are
+// reported together in a PopulateError.
+func Populate(resolver Resolver, target any) error {
+	targetVal := reflect.ValueOf(target)
+	if targetVal.Kind() != reflect.Ptr || targetVal.IsNil() || targetVal.Elem().Kind() != reflect.Struct {
+		return NewInvalidPopulateTargetError(fmt.Sprintf("got %T", target))
+	}
+	obj := targetVal.Elem()
+	t := obj.Type()
+
+	inj := newInjector(context.Background(), resolver)
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := field.Tag.Lookup("inject"); !ok {
+			continue
+		}
+		if !field.IsExported() {
+			errs = append(errs, NewFieldInjectionError(field.Name, NewInvalidPopulateTargetError("unexported fields cannot be injected")))
+			continue
+		}
+		if reason, ok := invalidFieldReason(field); ok {
+			errs = append(errs, NewFieldInjectionError(field.Name, NewInvalidPopulateTargetError(fmt.Sprintf("field %s %s", field.Name, reason))))
+			continue
+		}
+
+		p := taggedParam(field)
+		if p.dep.optional && !isResolvable(inj.container, p.dep.key) {
+			continue
+		}
+		val, err := p.inject(inj)
+		if err != nil {
+			errs = append(errs, NewFieldInjectionError(field.Name, err))
+			continue
+		}
+		obj.Field(i).Set(val)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewPopulateError(typeName(t), errs)
+}
+
+func MustPopulate(resolver Resolver, target any) {
+	err := Populate(resolver, target)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// isResolvable reports whether k is registered, taking the container lock.
+func isResolvable(container *Container, k key) bool {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+	return isRegistered(container, k)
+}
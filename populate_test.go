@@ -0,0 +1,106 @@
+package gotainer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestPopulate_TaggedFields_AreInjected(t *testing.T) {
+	c := gotainer.NewContainer()
+	registerReportDeps(c)
+	gotainer.MustRegisterSingleton[Database](c, NewDatabaseFactory("default-dsn"))
+	gotainer.MustRegisterSingleton[MetricsExporter](c, NewCountingMetricsExporter)
+	handler := FrameworkHandler{Route: "/reports"}
+
+	err := gotainer.Populate(c, &handler)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if handler.Primary.dsn != "default-dsn" || handler.Replica.dsn != "replica-dsn" {
+		t.Errorf("expected tagged fields to be injected, got %q and %q", handler.Primary.dsn, handler.Replica.dsn)
+	}
+	if handler.Metrics == nil || len(handler.Checks) != 2 {
+		t.Error("expected optional and group fields to be injected")
+	}
+	if handler.Route != "/reports" {
+		t.Errorf("expected untagged field to be left alone, got %q", handler.Route)
+	}
+}
+
+func TestPopulate_OptionalFieldNotRegistered_IsLeftAlone(t *testing.T) {
+	c := gotainer.NewContainer()
+	registerReportDeps(c)
+	gotainer.MustRegisterSingleton[Database](c, NewDatabaseFactory("default-dsn"))
+	existing := &CountingMetricsExporter{}
+	handler := FrameworkHandler{Metrics: existing}
+
+	gotainer.MustPopulate(c, &handler)
+
+	if handler.Metrics != existing {
+		t.Error("expected optional field to be left alone when not registered")
+	}
+}
+
+func TestPopulate_UnresolvableFields_ReturnsAggregatedError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Database](c, NewDatabaseFactory("default-dsn"))
+	handler := BadFrameworkHandler{}
+
+	err := gotainer.Populate(c, &handler)
+
+	populateErr := &gotainer.PopulateError{}
+	if !errors.As(err, &populateErr) {
+		t.Errorf("expected error to be PopulateError, got %v", err)
+		return
+	}
+	if len(populateErr.Errors) != 3 {
+		t.Errorf("expected 3 field errors, got %d: %v", len(populateErr.Errors), err)
+		return
+	}
+	fieldErr := &gotainer.FieldInjectionError{}
+	if !errors.As(populateErr.Errors[0], &fieldErr) || fieldErr.FieldName != "Analytics" {
+		t.Errorf("expected first error to be for Analytics, got %v", populateErr.Errors[0])
+	}
+	if !errors.As(err, new(*gotainer.TypeNotRegisteredError)) {
+		t.Error("expected missing registration to be reported as TypeNotRegisteredError")
+	}
+	if !errors.As(err, new(*gotainer.InvalidPopulateTargetError)) {
+		t.Error("expected invalid fields to be reported as InvalidPopulateTargetError")
+	}
+	if handler.Primary == nil {
+		t.Error("expected resolvable fields to be injected despite other failures")
+	}
+}
+
+func TestPopulate_NonPointerTarget_ReturnsInvalidPopulateTargetError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.Populate(c, FrameworkHandler{})
+
+	if !errors.As(err, new(*gotainer.InvalidPopulateTargetError)) {
+		t.Errorf("expected error to be InvalidPopulateTargetError, got %v", err)
+	}
+}
+
+func TestPopulate_FromScope_InjectsScopedFields(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterScoped[Database](c, NewDatabaseFactory("scoped-dsn"))
+	scope := c.NewScope()
+	target := struct {
+		DB *Database `inject:""`
+	}{}
+
+	err := gotainer.Populate(scope, &target)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if target.DB != gotainer.MustResolve[Database](scope) {
+		t.Error("expected field to be the scoped instance")
+	}
+}
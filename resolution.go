@@ -0,0 +1,107 @@
+package gotainer
+
+import (
+	"context"
+	"errors"
+)
+
+// resolution carries the state of a single resolve as it walks down the dependency graph.
+type resolution struct {
+	// ctx is passed to ctors which accept a context, and aborts the resolve once done.
+	ctx context.Context
+	// scope is nil when resolving from the root Container.
+	scope *Scope
+	path  []resolutionStep
+}
+
+type resolutionStep struct {
+	k        key
+	lifetime Lifetime
+}
+
+func newResolution(ctx context.Context, scope *Scope) *resolution {
+	return &resolution{ctx: ctx, scope: scope}
+}
+
+// enter returns the resolution for constructing k as a dependency of the current path, or a
+// CircularDependencyError if k is already being constructed further up the path.
+func (r *resolution) enter(k key, lifetime Lifetime) (*resolution, error) {
+	for i, step := range r.path {
+		if step.k == k {
+			cycle := make([]key, 0, len(r.path)-i+1)
+			for _, cycleStep := range r.path[i:] {
+				cycle = append(cycle, cycleStep.k)
+			}
+			return nil, newCircularDependencyError(append(cycle, k))
+		}
+	}
+
+	// copy so sibling dependencies never share a backing array
+	path := make([]resolutionStep, len(r.path), len(r.path)+1)
+	copy(path, r.path)
+	return &resolution{ctx: r.ctx, scope: r.scope, path: append(path, resolutionStep{k: k, lifetime: lifetime})}, nil
+}
+
+// constructingSingleton reports whether a singleton is on the path.
+func (r *resolution) constructingSingleton() bool {
+	for _, step := range r.path {
+		if step.lifetime == LifetimeSingleton {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapError attaches the current path to an error raised while constructing the last step, errors
+// which already carry a path from further down the graph are returned unchanged.
+func (r *resolution) wrapError(err error) error {
+	var resolutionErr *ResolutionError
+	if errors.As(err, &resolutionErr) {
+		return err
+	}
+
+	steps := make([]ResolutionStep, len(r.path))
+	for i, step := range r.path {
+		steps[i] = ResolutionStep{TypeName: step.k.String(), Lifetime: step.lifetime}
+	}
+	return NewResolutionError(steps, err)
+}
+
+// withScope returns the resolution with the same path but resolving against scope.
+func (r *resolution) withScope(scope *Scope) *resolution {
+	return &resolution{ctx: r.ctx, scope: scope, path: r.path}
+}
+
+// findCycle walks the registered dependencies of k, which has not been registered yet, looking for
+// a path back to k. Callers must hold the container lock.
+func findCycle(container *Container, k key, deps []dependency) []key {
+	visited := make(map[key]bool)
+	var walk func(path []key, deps []dependency) []key
+	walk = func(path []key, deps []dependency) []key {
+		for _, dep := range deps {
+			if dep.deferred {
+				continue
+			}
+			if dep.key == k {
+				return append(path, dep.key)
+			}
+			if visited[dep.key] {
+				continue
+			}
+			visited[dep.key] = true
+			if cycle := walk(append(path, dep.key), dependenciesOf(container, dep.key)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk([]key{k}, deps)
+}
+
+func newCircularDependencyError(cycle []key) *CircularDependencyError {
+	chain := make([]string, len(cycle))
+	for i, k := range cycle {
+		chain[i] = k.String()
+	}
+	return NewCircularDependencyError(chain)
+}
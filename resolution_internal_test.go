@@ -0,0 +1,36 @@
+package gotainer
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type cycleTypeA struct{ ref *cycleTypeB }
+type cycleTypeB struct{ ref *cycleTypeC }
+type cycleTypeC struct{ ref *cycleTypeA }
+
+func TestResolveNoReflect_RegistrationsFormingCycle_ReturnsCircularDependencyError(t *testing.T) {
+	c := NewContainer()
+	MustRegisterTransient[cycleTypeA](c, func() (*cycleTypeA, error) { return &cycleTypeA{}, nil })
+	MustRegisterTransient[cycleTypeC](c, func(a *cycleTypeA) (*cycleTypeC, error) { return &cycleTypeC{ref: a}, nil })
+	MustRegisterTransient[cycleTypeB](c, func(c *cycleTypeC) (*cycleTypeB, error) { return &cycleTypeB{ref: c}, nil })
+
+	// registration ordering prevents cycles, so close the loop behind the container's back
+	ctor := func(b *cycleTypeB) (*cycleTypeA, error) { return &cycleTypeA{ref: b}, nil }
+	c.registrations[keyOf[cycleTypeA]("")].ctor = wrapCtor(c, reflect.ValueOf(ctor), paramsOf(reflect.TypeOf(ctor), nil))
+
+	_, err := Resolve[cycleTypeA](c)
+
+	cycleErr := &CircularDependencyError{}
+	if !errors.As(err, &cycleErr) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+		return
+	}
+
+	expected := []string{"cycleTypeA", "cycleTypeB", "cycleTypeC", "cycleTypeA"}
+	if !slices.Equal(cycleErr.Chain, expected) {
+		t.Errorf("expected chain %v, got %v", expected, cycleErr.Chain)
+	}
+}
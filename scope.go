@@ -0,0 +1,96 @@
+package gotainer
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Scope caches scoped types for its lifetime, typically a single request or unit of work.
+// Singletons are still shared with the root Container and transients are constructed on every resolve.
+type Scope struct {
+	container    *Container
+	mu           sync.Mutex
+	instances    map[key]reflect.Value
+	constructMus map[key]*sync.Mutex
+	disposables  disposables
+}
+
+// NewScope creates a Scope which resolves against the registrations of the container.
+func (c *Container) NewScope() *Scope {
+	return &Scope{
+		container:    c,
+		instances:    make(map[key]reflect.Value),
+		constructMus: make(map[key]*sync.Mutex),
+	}
+}
+
+func (s *Scope) resolve(ctx context.Context, k key) (reflect.Value, error) {
+	if s.disposables.isClosed() || s.container.disposables.isClosed() {
+		return reflect.Value{}, NewContainerClosedError()
+	}
+	return resolveNoReflect(s.container, k, newResolution(ctx, s))
+}
+
+func (s *Scope) root() *Container {
+	return s.container
+}
+
+func (s *Scope) load(k key) (reflect.Value, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	instance, ok := s.instances[k]
+	return instance, ok
+}
+
+func (s *Scope) store(k key, instance reflect.Value) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances[k] = instance
+}
+
+// constructLock returns the lock guarding construction of k within this scope, a lock per key
+// lets scoped types depend on other scoped types without deadlocking.
+func (s *Scope) constructLock(k key) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mu, ok := s.constructMus[k]
+	if !ok {
+		mu = &sync.Mutex{}
+		s.constructMus[k] = mu
+	}
+	return mu
+}
+
+func wrapScopedCtor(k key, ctor ctorFunc, dispose disposer) ctorFunc {
+	return func(res *resolution) (reflect.Value, error) {
+		scope := res.scope
+		if scope == nil {
+			return reflect.Value{}, NewScopeRequiredError(k.String())
+		}
+
+		instance, ok := scope.load(k)
+		if ok {
+			return instance, nil
+		}
+
+		mu := scope.constructLock(k)
+		mu.Lock()
+		defer mu.Unlock()
+		instance, ok = scope.load(k)
+		if ok {
+			return instance, nil
+		}
+
+		constructed, err := ctor(res)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		err = scope.disposables.add(dispose(constructed))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		scope.store(k, constructed)
+		return constructed, nil
+	}
+}
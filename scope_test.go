@@ -0,0 +1,131 @@
+package gotainer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestScope_RegisterScopedSimpleStruct_ResolvesSameInstanceWithinScope(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterScoped[SimpleStruct](c, NewSimpleStruct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scope := c.NewScope()
+	first, err := gotainer.Resolve[SimpleStruct](scope)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	second, err := gotainer.Resolve[SimpleStruct](scope)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if first != second {
+		t.Error("scoped types resolved from the same scope should be the same reference")
+	}
+}
+
+func TestScope_RegisterScopedSimpleStruct_ResolvesDifferentInstancesAcrossScopes(t *testing.T) {
+	c := gotainer.NewContainer()
+	err := gotainer.RegisterScoped[SimpleStruct](c, NewSimpleStruct)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	first, err := gotainer.Resolve[SimpleStruct](c.NewScope())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	second, err := gotainer.Resolve[SimpleStruct](c.NewScope())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if first == second {
+		t.Error("scoped types resolved from different scopes should not be the same reference")
+	}
+}
+
+func TestScope_ResolveMixedLifetimes_RespectsEachLifetime(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+	gotainer.MustRegisterScoped[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterTransient[TierOneType](c, NewTierOneType)
+
+	rootSingleton := gotainer.MustResolve[TierTwoTypeOne](c)
+	scopeOne := c.NewScope()
+	scopeTwo := c.NewScope()
+	firstOne := gotainer.MustResolve[TierOneType](scopeOne)
+	secondOne := gotainer.MustResolve[TierOneType](scopeOne)
+	firstTwo := gotainer.MustResolve[TierOneType](scopeTwo)
+
+	if firstOne == secondOne {
+		t.Error("transients resolved from a scope should not be the same reference")
+	}
+	if firstOne.ref != rootSingleton || firstTwo.ref != rootSingleton {
+		t.Error("singletons resolved from a scope should be the root singleton")
+	}
+	if firstOne.ref2 != secondOne.ref2 {
+		t.Error("scoped dependencies within the same scope should be the same reference")
+	}
+	if firstOne.ref2 == firstTwo.ref2 {
+		t.Error("scoped dependencies from different scopes should not be the same reference")
+	}
+}
+
+func TestScope_ResolveScopedFromRoot_ReturnsScopeRequiredError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterScoped[SimpleStruct](c, NewSimpleStruct)
+
+	_, err := gotainer.Resolve[SimpleStruct](c)
+	if err == nil {
+		t.Error("expected error when resolving a scoped type from the root container")
+		return
+	}
+
+	scopeErr := &gotainer.ScopeRequiredError{}
+	if !errors.As(err, &scopeErr) {
+		t.Errorf("expected error to be ScopeRequiredError, got %v", err)
+		return
+	}
+
+	if scopeErr.TypeName != "SimpleStruct" {
+		t.Errorf("expected error to be for SimpleStruct was for %s", scopeErr.TypeName)
+	}
+}
+
+func TestScope_RegisterSingletonDependingOnScoped_ReturnsScopedDependencyError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+	gotainer.MustRegisterScoped[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterTransient[TierOneType](c, NewTierOneType)
+
+	err := gotainer.RegisterSingleton[TierZeroType](c, NewTierZeroType)
+	if err == nil {
+		t.Error("expected error when a singleton depends on a scoped type through a transient")
+		return
+	}
+
+	scopedErr := &gotainer.ScopedDependencyError{}
+	if !errors.As(err, &scopedErr) {
+		t.Errorf("expected error to be ScopedDependencyError, got %v", err)
+		return
+	}
+
+	if scopedErr.ParentTypeName != "TierZeroType" {
+		t.Errorf("expected error to be for TierZeroType was for %s", scopedErr.ParentTypeName)
+	}
+	if scopedErr.DependencyName != "TierTwoTypeTwo" {
+		t.Errorf("expected error to be for TierTwoTypeTwo was for %s", scopedErr.DependencyName)
+	}
+}
@@ -0,0 +1,184 @@
+package gotainer
+
+import (
+	"context"
+	"slices"
+)
+
+// Start constructs every singleton registered Eager in dependency order, so that misconfiguration
+// fails at startup rather than on the first resolve. Singletons depending on one which failed are
+// skipped, every failure is returned together in a StartError. Independent singletons may be
+// constructed concurrently WithWorkers, the result is the same as constructing them one at a time.
+func (c *Container) Start(ctx context.Context, opts ...StartOption) error {
+	return c.startSingletons(ctx, func(reg *registration) bool {
+		return reg.eager
+	}, opts)
+}
+
+// InitSingletons is Start constructing every registered singleton, whether registered Eager or not.
+func (c *Container) InitSingletons(ctx context.Context, opts ...StartOption) error {
+	return c.startSingletons(ctx, func(*registration) bool {
+		return true
+	}, opts)
+}
+
+func (c *Container) startSingletons(ctx context.Context, selected func(reg *registration) bool, opts []StartOption) error {
+	if c.disposables.isClosed() {
+		return NewContainerClosedError()
+	}
+	options := newStartOptions(opts)
+	c.mu.RLock()
+	nodes := startOrder(c, selected)
+	c.mu.RUnlock()
+
+	errs := startNodes(ctx, c, nodes, options.workers)
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewStartError(errs)
+}
+
+// startNodes constructs each node once every node it depends on has been constructed, running at
+// most workers ctors at once. Errors are returned in node order whichever order the nodes finish in,
+// followed by the context error if ctx was done before every node was constructed.
+func startNodes(ctx context.Context, container *Container, nodes []startNode, workers int) []error {
+	index := make(map[key]int, len(nodes))
+	for i, node := range nodes {
+		index[node.k] = i
+	}
+	pending := make([]int, len(nodes))
+	dependents := make([][]int, len(nodes))
+	var ready []int
+	for i, node := range nodes {
+		pending[i] = len(node.deps)
+		for _, dep := range node.deps {
+			dependents[index[dep]] = append(dependents[index[dep]], i)
+		}
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	errs := make([]error, len(nodes))
+	// failed nodes include those skipped because a dependency failed
+	failed := make([]bool, len(nodes))
+	finished := 0
+	var finish func(i int)
+	finish = func(i int) {
+		finished++
+		for _, dependent := range dependents[i] {
+			failed[dependent] = failed[dependent] || failed[i]
+			pending[dependent]--
+			if pending[dependent] != 0 {
+				continue
+			}
+			if failed[dependent] {
+				// the failure has already been reported for the dependency
+				finish(dependent)
+				continue
+			}
+			ready = append(ready, dependent)
+		}
+	}
+
+	type result struct {
+		i   int
+		err error
+	}
+	results := make(chan result)
+	running := 0
+	for finished < len(nodes) {
+		for running < workers && len(ready) > 0 && ctx.Err() == nil {
+			// the earliest node goes first, so a single worker constructs in node order
+			next := slices.Min(ready)
+			ready = slices.DeleteFunc(ready, func(i int) bool { return i == next })
+			running++
+			go func() {
+				_, err := container.resolve(ctx, nodes[next].k)
+				results <- result{i: next, err: err}
+			}()
+		}
+		if running == 0 {
+			// with nothing running every remaining node would be ready, unless ctx is done
+			break
+		}
+
+		res := <-results
+		running--
+		if res.err != nil {
+			errs[res.i] = res.err
+			failed[res.i] = true
+		}
+		finish(res.i)
+	}
+
+	var ordered []error
+	for _, err := range errs {
+		if err != nil {
+			ordered = append(ordered, err)
+		}
+	}
+	if finished < len(nodes) {
+		ordered = append(ordered, ctx.Err())
+	}
+	return ordered
+}
+
+// startNode is a singleton to construct on start, deps are the other started singletons it
+// depends on, directly or through registrations which are not started.
+type startNode struct {
+	k    key
+	deps []key
+}
+
+// startOrder returns the selected singletons in dependency order, walking keys in registration
+// order. Cycles are left for the resolve to report. Callers must hold the container lock.
+func startOrder(container *Container, selected func(reg *registration) bool) []startNode {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[key]int)
+	// reached holds the selected singletons each walked key depends on, or the key itself if selected
+	reached := make(map[key][]key)
+	var nodes []startNode
+
+	var walk func(k key)
+	walk = func(k key) {
+		state[k] = visiting
+		var deps []key
+		for _, edge := range dependenciesOf(container, k) {
+			if edge.deferred {
+				continue
+			}
+			switch state[edge.key] {
+			case unvisited:
+				walk(edge.key)
+			case visiting:
+				continue
+			}
+			for _, dep := range reached[edge.key] {
+				if !slices.Contains(deps, dep) {
+					deps = append(deps, dep)
+				}
+			}
+		}
+		state[k] = done
+
+		reg, ok := container.registrations[k]
+		if ok && reg.lifetime == LifetimeSingleton && selected(reg) {
+			nodes = append(nodes, startNode{k: k, deps: deps})
+			reached[k] = []key{k}
+			return
+		}
+		reached[k] = deps
+	}
+
+	for _, k := range container.order {
+		if state[k] == unvisited {
+			walk(k)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,201 @@
+package gotainer_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestStart_EagerSingletons_ConstructsOnlyEagerSingletons(t *testing.T) {
+	c := gotainer.NewContainer()
+	log := &StartLog{}
+	gotainer.MustRegisterSingleton[Config](c, NewConfigFactory(log), gotainer.Eager())
+	gotainer.MustRegisterSingleton[Mailer](c, NewMailerFactory(log))
+
+	err := c.Start(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if built := log.Built(); !slices.Equal(built, []string{"config"}) {
+		t.Errorf("expected only the eager singleton to be built, got %v", built)
+	}
+}
+
+func TestStart_EagerSingleton_ResolvesSameInstance(t *testing.T) {
+	c := gotainer.NewContainer()
+	log := &StartLog{}
+	gotainer.MustRegisterSingleton[Config](c, NewConfigFactory(log), gotainer.Eager())
+
+	err := c.Start(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	gotainer.MustResolve[Config](c)
+
+	if built := log.Built(); len(built) != 1 {
+		t.Errorf("expected the eager singleton to be built once, got %v", built)
+	}
+}
+
+func TestInitSingletons_RegisteredOutOfOrder_ConstructsInDependencyOrder(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	log := &StartLog{}
+	gotainer.MustRegisterSingleton[Cache](c, NewCacheFactory(log))
+	gotainer.MustRegisterSingleton[Mailer](c, NewMailerFactory(log))
+	gotainer.MustRegisterTransient[Templates](c, NewTemplatesFactory(log))
+	gotainer.MustRegisterSingleton[Config](c, NewConfigFactory(log))
+
+	err := c.InitSingletons(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{"config", "templates", "cache", "mailer"}
+	if built := log.Built(); !slices.Equal(built, expected) {
+		t.Errorf("expected singletons to be built in dependency order %v, got %v", expected, built)
+	}
+}
+
+func TestInitSingletons_FailingSingletons_ReturnsEveryFailure(t *testing.T) {
+	c := gotainer.NewContainer()
+	log := &StartLog{}
+	configErr := errors.New("config error")
+	mailerErr := errors.New("mailer error")
+	gotainer.MustRegisterSingleton[Config](c, NewFailingConfigFactory(configErr))
+	gotainer.MustRegisterSingleton[Templates](c, NewTemplatesFactory(log))
+	gotainer.MustRegisterSingleton[Mailer](c, NewFailingMailerFactory(mailerErr))
+
+	err := c.InitSingletons(context.Background())
+
+	var startErr *gotainer.StartError
+	if !errors.As(err, &startErr) {
+		t.Errorf("expected StartError, got %v", err)
+		return
+	}
+	if len(startErr.Errors) != 2 {
+		t.Errorf("expected 2 errors, the dependent singleton being skipped, got %v", startErr.Errors)
+		return
+	}
+	if !errors.Is(err, configErr) || !errors.Is(err, mailerErr) {
+		t.Errorf("expected both ctor errors to be reported, got %v", err)
+		return
+	}
+	if built := log.Built(); len(built) != 0 {
+		t.Errorf("expected the dependent of the failed singleton not to be built, got %v", built)
+	}
+}
+
+func TestStart_CancelledContext_ReturnsContextError(t *testing.T) {
+	c := gotainer.NewContainer()
+	log := &StartLog{}
+	gotainer.MustRegisterSingleton[Config](c, NewConfigFactory(log), gotainer.Eager())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.Start(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap context.Canceled, got %v", err)
+		return
+	}
+	if built := log.Built(); len(built) != 0 {
+		t.Errorf("expected nothing to be built, got %v", built)
+	}
+}
+
+func TestStart_ClosedContainer_ReturnsContainerClosedError(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[Config](c, NewConfigFactory(&StartLog{}), gotainer.Eager())
+	_ = c.Close(context.Background())
+
+	err := c.Start(context.Background())
+
+	var closedErr *gotainer.ContainerClosedError
+	if !errors.As(err, &closedErr) {
+		t.Errorf("expected ContainerClosedError, got %v", err)
+	}
+}
+
+func TestRegister_EagerTransient_ReturnsConstructorMismatchError(t *testing.T) {
+	c := gotainer.NewContainer()
+
+	err := gotainer.RegisterTransient[Config](c, NewConfigFactory(&StartLog{}), gotainer.Eager())
+
+	var mismatchErr *gotainer.ConstructorMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Errorf("expected ConstructorMismatchError, got %v", err)
+	}
+}
+
+func TestStart_WithWorkers_ConstructsIndependentSingletonsConcurrently(t *testing.T) {
+	c := gotainer.NewContainer()
+	barrier := NewStartBarrier(2)
+	gotainer.MustRegisterSingleton[ConfigParser](c, NewConfigParserFactory(barrier), gotainer.Eager())
+	gotainer.MustRegisterSingleton[TemplateCompiler](c, NewTemplateCompilerFactory(barrier), gotainer.Eager())
+
+	err := c.Start(context.Background(), gotainer.WithWorkers(2))
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInitSingletons_WithWorkers_ConstructsDependenciesFirst(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	log := &StartLog{}
+	gotainer.MustRegisterSingleton[Cache](c, NewCacheFactory(log))
+	gotainer.MustRegisterSingleton[Templates](c, NewTemplatesFactory(log))
+	gotainer.MustRegisterSingleton[Mailer](c, NewMailerFactory(log))
+	gotainer.MustRegisterSingleton[Config](c, NewConfigFactory(log))
+
+	err := c.InitSingletons(context.Background(), gotainer.WithWorkers(4))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	built := log.Built()
+	if len(built) != 4 {
+		t.Errorf("expected every singleton to be built once, got %v", built)
+		return
+	}
+	config, templates, cache := slices.Index(built, "config"), slices.Index(built, "templates"), slices.Index(built, "cache")
+	if config > templates || templates > cache {
+		t.Errorf("expected singletons to be built after their dependencies, got %v", built)
+	}
+}
+
+func TestInitSingletons_WithWorkers_ReturnsSameErrorsAsSerial(t *testing.T) {
+	configErr := errors.New("config error")
+	mailerErr := errors.New("mailer error")
+	newContainer := func() *gotainer.Container {
+		c := gotainer.NewContainer()
+		gotainer.MustRegisterSingleton[Config](c, NewFailingConfigFactory(configErr))
+		gotainer.MustRegisterSingleton[Templates](c, NewTemplatesFactory(&StartLog{}))
+		gotainer.MustRegisterSingleton[Mailer](c, NewFailingMailerFactory(mailerErr))
+		return c
+	}
+
+	serialErr := newContainer().InitSingletons(context.Background())
+	parallelErr := newContainer().InitSingletons(context.Background(), gotainer.WithWorkers(4))
+
+	var startErr *gotainer.StartError
+	if !errors.As(parallelErr, &startErr) {
+		t.Errorf("expected StartError, got %v", parallelErr)
+		return
+	}
+	if len(startErr.Errors) != 2 || !errors.Is(startErr.Errors[0], configErr) || !errors.Is(startErr.Errors[1], mailerErr) {
+		t.Errorf("expected the config then the mailer error, got %v", startErr.Errors)
+		return
+	}
+	if parallelErr.Error() != serialErr.Error() {
+		t.Errorf("expected the same error as a serial start, got %v, want %v", parallelErr, serialErr)
+	}
+}
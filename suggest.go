@@ -0,0 +1,62 @@
+package gotainer
+
+import (
+	"reflect"
+	"strings"
+)
+
+// maxSuggestionDistance is how many single character edits a registered type name may be from the
+// requested one and still be suggested, short names are allowed proportionally fewer edits.
+const maxSuggestionDistance = 2
+
+// findSimilarRegistrations returns the qualified names of registrations which the caller may have
+// meant instead of k, in registration order. Other names of the same type are always suggested.
+func findSimilarRegistrations(container *Container, k key) []string {
+	container.mu.RLock()
+	defer container.mu.RUnlock()
+
+	var suggestions []string
+	for _, registered := range container.order {
+		if registered.t == k.t || (registered.name == k.name && isSimilarType(k.t, registered.t)) {
+			suggestions = append(suggestions, registered.qualifiedName())
+		}
+	}
+	return suggestions
+}
+
+func isSimilarType(requested reflect.Type, registered reflect.Type) bool {
+	// a pointer was requested for a type registered by value, or the other way around
+	if requested.Kind() == reflect.Ptr && requested.Elem() == registered {
+		return true
+	}
+	if registered.Kind() == reflect.Ptr && registered.Elem() == requested {
+		return true
+	}
+
+	requestedName := strings.ToLower(typeName(requested))
+	registeredName := strings.ToLower(typeName(registered))
+	maxDistance := min(maxSuggestionDistance, len(requestedName)/4)
+	return editDistance(requestedName, registeredName) <= maxDistance
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
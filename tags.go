@@ -0,0 +1,34 @@
+package gotainer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// injectTag is the parsed form of an `inject:"..."` struct tag, a comma separated list of options.
+type injectTag struct {
+	name     string
+	optional bool
+	group    bool
+}
+
+func parseInjectTag(tag string) (injectTag, error) {
+	var parsed injectTag
+	if tag == "" {
+		return parsed, nil
+	}
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		switch {
+		case option == "optional":
+			parsed.optional = true
+		case option == "group":
+			parsed.group = true
+		case strings.HasPrefix(option, "name="):
+			parsed.name = strings.TrimPrefix(option, "name=")
+		default:
+			return parsed, fmt.Errorf("unknown option %q", option)
+		}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,84 @@
+package gotainer
+
+// Validate checks the whole dependency graph, reporting every missing dependency, every cycle and
+// every singleton which captures a scoped type. All problems are returned together in a
+// ValidationError. It is required when the container was created WithDeferredValidation, and
+// safe to call on any container.
+func (c *Container) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var errs []error
+	for _, k := range c.order {
+		for _, dep := range c.registrations[k].deps {
+			if !dep.optional && !isRegistered(c, dep.key) {
+				errs = append(errs, NewMissingDependencyError(k.String(), dep.key.String()))
+			}
+		}
+	}
+
+	for _, cycle := range findAllCycles(c) {
+		errs = append(errs, newCircularDependencyError(cycle))
+	}
+
+	for _, k := range c.order {
+		reg := c.registrations[k]
+		if reg.lifetime != LifetimeSingleton {
+			continue
+		}
+		if err := findScopedDependencyErrors(c, reg.deps, k); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewValidationError(errs)
+}
+
+// findAllCycles returns each cycle in the registered graph once, walking keys in registration
+// order. Callers must hold the container lock.
+func findAllCycles(container *Container) [][]key {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[key]int)
+	var cycles [][]key
+	var path []key
+
+	var walk func(k key)
+	walk = func(k key) {
+		state[k] = visiting
+		path = append(path, k)
+		for _, edge := range dependenciesOf(container, k) {
+			if edge.deferred {
+				continue
+			}
+			dep := edge.key
+			switch state[dep] {
+			case unvisited:
+				walk(dep)
+			case visiting:
+				start := len(path) - 1
+				for path[start] != dep {
+					start--
+				}
+				cycle := make([]key, 0, len(path)-start+1)
+				cycle = append(cycle, path[start:]...)
+				cycles = append(cycles, append(cycle, dep))
+			}
+		}
+		path = path[:len(path)-1]
+		state[k] = done
+	}
+
+	for _, k := range container.order {
+		if state[k] == unvisited {
+			walk(k)
+		}
+	}
+	return cycles
+}
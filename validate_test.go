@@ -0,0 +1,138 @@
+package gotainer_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/BlindGarret/gotainer"
+)
+
+func TestValidate_DeferredRegistrationOutOfOrder_ValidatesAndResolves(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterSingleton[TierZeroType](c, NewTierZeroType)
+	gotainer.MustRegisterSingleton[TierOneType](c, NewTierOneType)
+	gotainer.MustRegisterTransient[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterTransient[TierTwoTypeOne](c, NewTierTwoTypeOne)
+
+	err := c.Validate()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	resolved, err := gotainer.Resolve[TierZeroType](c)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if resolved.ref == nil || resolved.ref.ref == nil || resolved.ref.ref2 == nil {
+		t.Error("expected every dependency to be resolved")
+	}
+}
+
+func TestValidate_DeferredRegistrationWithMissingTypes_ListsEveryMissingDependency(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterSingleton[TierZeroType](c, NewTierZeroType)
+	gotainer.MustRegisterSingleton[TierOneType](c, NewTierOneType)
+
+	err := c.Validate()
+	if err == nil {
+		t.Error("expected error when validating a graph with missing dependencies")
+		return
+	}
+
+	validationErr := &gotainer.ValidationError{}
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected error to be ValidationError, got %v", err)
+		return
+	}
+
+	var missing []string
+	for _, err := range validationErr.Errors {
+		missingErr := &gotainer.MissingDependencyError{}
+		if !errors.As(err, &missingErr) {
+			t.Errorf("expected error to be MissingDependencyError, got %v", err)
+			return
+		}
+		missing = append(missing, missingErr.ParentTypeName+" -> "+missingErr.DependencyName)
+	}
+
+	expected := []string{"TierOneType -> TierTwoTypeOne", "TierOneType -> TierTwoTypeTwo"}
+	if !slices.Equal(missing, expected) {
+		t.Errorf("expected missing dependencies %v, got %v", expected, missing)
+	}
+}
+
+func TestValidate_DeferredRegistrationWithCycle_ReturnsCircularDependencyError(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterSingleton[CycleTypeA](c, NewCycleTypeA)
+	gotainer.MustRegisterSingleton[CycleTypeB](c, NewCycleTypeB)
+	gotainer.MustRegisterSingleton[CycleTypeC](c, NewCycleTypeC)
+
+	err := c.Validate()
+
+	cycleErr := &gotainer.CircularDependencyError{}
+	if !errors.As(err, &cycleErr) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+		return
+	}
+
+	expected := []string{"CycleTypeA", "CycleTypeB", "CycleTypeC", "CycleTypeA"}
+	if !slices.Equal(cycleErr.Chain, expected) {
+		t.Errorf("expected chain %v, got %v", expected, cycleErr.Chain)
+	}
+}
+
+func TestValidate_DeferredRegistrationWithCycleResolvedUnvalidated_ReturnsCircularDependencyError(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterTransient[CycleTypeA](c, NewCycleTypeA)
+	gotainer.MustRegisterTransient[CycleTypeB](c, NewCycleTypeB)
+	gotainer.MustRegisterTransient[CycleTypeC](c, NewCycleTypeC)
+
+	_, err := gotainer.Resolve[CycleTypeB](c)
+
+	cycleErr := &gotainer.CircularDependencyError{}
+	if !errors.As(err, &cycleErr) {
+		t.Errorf("expected error to be CircularDependencyError, got %v", err)
+		return
+	}
+
+	expected := []string{"CycleTypeB", "CycleTypeC", "CycleTypeA", "CycleTypeB"}
+	if !slices.Equal(cycleErr.Chain, expected) {
+		t.Errorf("expected chain %v, got %v", expected, cycleErr.Chain)
+	}
+}
+
+func TestValidate_DeferredSingletonCapturingScoped_ReturnsScopedDependencyError(t *testing.T) {
+	c := gotainer.NewContainer(gotainer.WithDeferredValidation())
+	gotainer.MustRegisterSingleton[TierOneType](c, NewTierOneType)
+	gotainer.MustRegisterScoped[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+
+	err := c.Validate()
+
+	scopedErr := &gotainer.ScopedDependencyError{}
+	if !errors.As(err, &scopedErr) {
+		t.Errorf("expected error to be ScopedDependencyError, got %v", err)
+		return
+	}
+
+	if scopedErr.ParentTypeName != "TierOneType" || scopedErr.DependencyName != "TierTwoTypeTwo" {
+		t.Errorf("expected error for TierOneType capturing TierTwoTypeTwo, got %v", scopedErr)
+	}
+}
+
+func TestValidate_StrictRegistration_ReturnsNil(t *testing.T) {
+	c := gotainer.NewContainer()
+	gotainer.MustRegisterSingleton[TierTwoTypeOne](c, NewTierTwoTypeOne)
+	gotainer.MustRegisterSingleton[TierTwoTypeTwo](c, NewTierTwoTypeTwo)
+	gotainer.MustRegisterSingleton[TierOneType](c, NewTierOneType)
+
+	err := c.Validate()
+
+	if err != nil {
+		t.Error(err)
+	}
+}